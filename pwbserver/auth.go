@@ -0,0 +1,33 @@
+package pwbserver
+
+// TokenVerifier resolves the token of Auth.Authenticate to a player ID.
+type TokenVerifier interface {
+	VerifyToken(token string) (playerID string, err error)
+}
+
+type TokenVerifierFunc func(token string) (string, error)
+
+func (fn TokenVerifierFunc) VerifyToken(token string) (string, error) {
+	return fn(token)
+}
+
+// NewInsecureTokenVerifier treats token as player ID, it is only for local play and tests.
+func NewInsecureTokenVerifier() TokenVerifier {
+	return TokenVerifierFunc(func(token string) (string, error) {
+		if token == "" {
+			return "", ErrServerUnauthorized
+		}
+		return token, nil
+	})
+}
+
+// NewStaticTokenVerifier accepts tokens in the given token to player ID map.
+func NewStaticTokenVerifier(tokens map[string]string) TokenVerifier {
+	return TokenVerifierFunc(func(token string) (string, error) {
+		playerID, exist := tokens[token]
+		if !exist {
+			return "", ErrServerUnauthorized
+		}
+		return playerID, nil
+	})
+}
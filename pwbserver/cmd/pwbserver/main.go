@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/weedbox/PokerWeedBox/pwbserver"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "listening address")
+	path := flag.String("path", "/v1/client-agent", "WebSocket path of client agent")
+	webDir := flag.String("web", "", "directory of the Unity WebGL build, served at / if set")
+	competitionID := flag.String("competition", "local", "competition ID of the local cash tables")
+	tableCount := flag.Int("tables", 1, "number of cash tables")
+	seatCount := flag.Int("seats", 9, "max seat count of each table")
+	actionTime := flag.Int("action-time", 15, "action time in seconds")
+	sb := flag.Int64("sb", 10, "small blind")
+	bb := flag.Int64("bb", 20, "big blind")
+	flag.Parse()
+
+	// token is used as player ID, which is only suitable for local play
+	manager := pwbtable.NewManager()
+	server := pwbserver.NewServer(manager, pwbserver.NewInsecureTokenVerifier())
+
+	options := pwbtable.NewTableEngineOptions()
+	options.Interval = 3
+	for i := 0; i < *tableCount; i++ {
+		table, err := server.CreateTable(options, pwbtable.TableSetting{
+			TableID: fmt.Sprintf("%s-%d", *competitionID, i+1),
+			Meta: pwbtable.TableMeta{
+				CompetitionID:       *competitionID,
+				Rule:                pwbtable.CompetitionRule_Default,
+				Mode:                pwbtable.CompetitionMode_Cash,
+				TableMaxSeatCount:   *seatCount,
+				TableMinPlayerCount: 2,
+				MinChipUnit:         *sb,
+				ActionTime:          *actionTime,
+			},
+		})
+		if err != nil {
+			log.Fatalf("failed to create table: %v", err)
+		}
+
+		if err := manager.UpdateBlind(table.ID, 1, 0, 0, *sb, *bb); err != nil {
+			log.Fatalf("failed to update blind: %v", err)
+		}
+		log.Printf("table %s of competition %s is created", table.ID, *competitionID)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(*path, server)
+	if *webDir != "" {
+		mux.Handle("/", http.FileServer(http.Dir(*webDir)))
+	}
+
+	log.Printf("listening on %s, client agent at %s", *addr, *path)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
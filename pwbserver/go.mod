@@ -0,0 +1,22 @@
+module github.com/weedbox/PokerWeedBox/pwbserver
+
+go 1.19
+
+require (
+	github.com/google/uuid v1.6.0
+	github.com/gorilla/websocket v1.5.3
+	github.com/stretchr/testify v1.9.0
+	github.com/weedbox/PokerWeedBox/pwbtable v0.0.0
+	github.com/weedbox/pokerface v0.1.2
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/thoas/go-funk v0.9.3 // indirect
+	github.com/weedbox/syncsaga v0.0.0-20230821071725-a634f0872340 // indirect
+	github.com/weedbox/timebank v0.0.0-20230713013837-bd7a6f808e3e // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/weedbox/PokerWeedBox/pwbtable => ../pwbtable
@@ -0,0 +1,357 @@
+package pwbserver
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+)
+
+var (
+	ErrServerCompetitionNotFound = errors.New("server: competition not found")
+	ErrServerTableNotFound       = errors.New("server: table not found")
+	ErrServerNoEmptyTable        = errors.New("server: no table with empty seat")
+	ErrServerUnknownWagerAction  = errors.New("server: unknown wager action")
+)
+
+type AutoModeUpdated struct {
+	CompetitionID string `json:"competition_id"`
+	TableID       string `json:"table_id"`
+	IsOn          bool   `json:"is_on"`
+}
+
+type CompetitionSummary struct {
+	ID       string                 `json:"id"`
+	Meta     CompetitionSummaryMeta `json:"meta"`
+	TableIDs []string               `json:"table_ids"`
+	UpdateAt int64                  `json:"update_at"`
+}
+
+type CompetitionSummaryMeta struct {
+	Rule                string `json:"rule"`
+	Mode                string `json:"mode"`
+	MaxDuration         int    `json:"max_duration"`
+	TableMaxSeatCount   int    `json:"table_max_seat_count"`
+	TableMinPlayerCount int    `json:"table_min_player_count"`
+	MinChipUnit         int64  `json:"min_chip_unit"`
+	ActionTime          int    `json:"action_time"`
+}
+
+func (s *Server) registerMethods() {
+	// System
+	s.HandlePublic("System.DeepPing", s.handleDeepPing)
+	s.HandlePublic("Auth.Authenticate", s.handleAuthenticate)
+	s.Handle("System.Ready", func(*Session, Params) (interface{}, error) { return nil, nil })
+
+	// Match
+	s.Handle("Match.CompetitionGetLatest", s.handleCompetitionGetLatest)
+	s.Handle("Match.UpdateCompetitionEventSubscribeStates", s.handleUpdateCompetitionEventSubscribeStates)
+	s.Handle("Match.CompetitionCashBuyIn", s.handleCompetitionCashBuyIn)
+	s.Handle("Match.CompetitionCashOut", s.handleTableLeave)
+	s.Handle("Match.TableGetLatest", s.handleTableGetLatest)
+	s.Handle("Match.TableJoin", s.handleTableJoin)
+	s.Handle("Match.TableLeave", s.handleTableLeave)
+	s.Handle("Match.GamePlayerReady", s.handleGamePlayerReady)
+	s.Handle("Match.GamePlayerWager", s.handleGamePlayerWager)
+	s.Handle("Match.GamePlayerAutoMode", s.handleGamePlayerAutoMode)
+}
+
+func (s *Server) handleDeepPing(session *Session, params Params) (interface{}, error) {
+	clientTimestamp, err := params.Int64(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]int64{
+		"client_timestamp": clientTimestamp,
+		"server_timestamp": time.Now().UnixMilli(),
+	}, nil
+}
+
+func (s *Server) handleAuthenticate(session *Session, params Params) (interface{}, error) {
+	token, err := params.String(0)
+	if err != nil {
+		return nil, err
+	}
+
+	playerID, err := s.verifier.VerifyToken(token)
+	if err != nil {
+		return nil, NewError(ErrorCode_Unauthorized, err.Error())
+	}
+
+	s.authenticate(session, playerID)
+	return nil, nil
+}
+
+func (s *Server) handleCompetitionGetLatest(session *Session, params Params) (interface{}, error) {
+	competitionID, err := params.String(0)
+	if err != nil {
+		return nil, err
+	}
+
+	tableIDs := s.competitionTableIDs(competitionID)
+	if len(tableIDs) == 0 {
+		return nil, ErrServerCompetitionNotFound
+	}
+
+	table, err := s.getTable(competitionID, tableIDs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"competition": CompetitionSummary{
+			ID: competitionID,
+			Meta: CompetitionSummaryMeta{
+				Rule:                table.Meta.Rule,
+				Mode:                table.Meta.Mode,
+				MaxDuration:         table.Meta.MaxDuration,
+				TableMaxSeatCount:   table.Meta.TableMaxSeatCount,
+				TableMinPlayerCount: table.Meta.TableMinPlayerCount,
+				MinChipUnit:         table.Meta.MinChipUnit,
+				ActionTime:          table.Meta.ActionTime,
+			},
+			TableIDs: tableIDs,
+			UpdateAt: table.UpdateAt,
+		},
+	}, nil
+}
+
+func (s *Server) handleUpdateCompetitionEventSubscribeStates(session *Session, params Params) (interface{}, error) {
+	data, err := params.String(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var req struct {
+		EventSubscribeStates []struct {
+			CompetitionID     string `json:"competition_id"`
+			IsEventSubscribed bool   `json:"is_event_subscribed"`
+		} `json:"event_subscribe_states"`
+	}
+	if err := json.Unmarshal([]byte(data), &req); err != nil {
+		return nil, ErrServerInvalidParams
+	}
+
+	for _, state := range req.EventSubscribeStates {
+		for _, tableID := range s.competitionTableIDs(state.CompetitionID) {
+			if state.IsEventSubscribed {
+				session.Subscribe(tableID)
+			} else {
+				session.Unsubscribe(tableID)
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *Server) handleCompetitionCashBuyIn(session *Session, params Params) (interface{}, error) {
+	competitionID, err := params.String(0)
+	if err != nil {
+		return nil, err
+	}
+
+	chips, err := params.Int64(1)
+	if err != nil {
+		return nil, err
+	}
+
+	// player who is already at a table buys in again at the same table
+	tableID := ""
+	for _, candidateID := range s.competitionTableIDs(competitionID) {
+		table, err := s.getTable(competitionID, candidateID)
+		if err != nil {
+			continue
+		}
+
+		if table.FindPlayerIdx(session.PlayerID()) != pwbtable.UnsetValue {
+			tableID = candidateID
+			break
+		}
+
+		if tableID == "" && len(table.State.PlayerStates) < table.Meta.TableMaxSeatCount {
+			tableID = candidateID
+		}
+	}
+
+	if tableID == "" {
+		return nil, ErrServerNoEmptyTable
+	}
+
+	joinPlayer := pwbtable.JoinPlayer{
+		PlayerID:    session.PlayerID(),
+		RedeemChips: chips,
+		Seat:        pwbtable.UnsetValue,
+	}
+	if err := s.manager.PlayerReserve(tableID, joinPlayer); err != nil {
+		return nil, err
+	}
+
+	session.Subscribe(tableID)
+
+	return map[string]string{
+		"table_id": tableID,
+	}, nil
+}
+
+func (s *Server) handleTableGetLatest(session *Session, params Params) (interface{}, error) {
+	competitionID, tableID, err := tableParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := s.getTable(competitionID, tableID)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := table.ViewFor(session.PlayerID())
+	if err != nil {
+		return nil, err
+	}
+
+	session.Subscribe(tableID)
+
+	return map[string]interface{}{
+		"table": view,
+	}, nil
+}
+
+func (s *Server) handleTableJoin(session *Session, params Params) (interface{}, error) {
+	competitionID, tableID, err := tableParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getTable(competitionID, tableID); err != nil {
+		return nil, err
+	}
+
+	session.Subscribe(tableID)
+	return nil, s.manager.PlayerJoin(tableID, session.PlayerID())
+}
+
+func (s *Server) handleTableLeave(session *Session, params Params) (interface{}, error) {
+	competitionID, tableID, err := tableParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getTable(competitionID, tableID); err != nil {
+		return nil, err
+	}
+
+	if err := s.manager.PlayersLeave(tableID, []string{session.PlayerID()}); err != nil {
+		return nil, err
+	}
+
+	session.Unsubscribe(tableID)
+	return nil, nil
+}
+
+func (s *Server) handleGamePlayerReady(session *Session, params Params) (interface{}, error) {
+	competitionID, tableID, err := tableParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getTable(competitionID, tableID); err != nil {
+		return nil, err
+	}
+
+	return nil, s.manager.PlayerReady(tableID, session.PlayerID())
+}
+
+func (s *Server) handleGamePlayerWager(session *Session, params Params) (interface{}, error) {
+	competitionID, tableID, err := tableParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	action, err := params.String(2)
+	if err != nil {
+		return nil, err
+	}
+
+	chips, err := params.Int64(3)
+	if err != nil {
+		chips = 0
+	}
+
+	if _, err := s.getTable(competitionID, tableID); err != nil {
+		return nil, err
+	}
+
+	playerID := session.PlayerID()
+	switch action {
+	case pwbtable.WagerAction_Fold:
+		return nil, s.manager.PlayerFold(tableID, playerID)
+	case pwbtable.WagerAction_Check:
+		return nil, s.manager.PlayerCheck(tableID, playerID)
+	case pwbtable.WagerAction_Call:
+		return nil, s.manager.PlayerCall(tableID, playerID)
+	case pwbtable.WagerAction_AllIn:
+		return nil, s.manager.PlayerAllin(tableID, playerID)
+	case pwbtable.WagerAction_Bet:
+		return nil, s.manager.PlayerBet(tableID, playerID, chips)
+	case pwbtable.WagerAction_Raise:
+		return nil, s.manager.PlayerRaise(tableID, playerID, chips)
+	case pwbtable.Action_Pay:
+		return nil, s.manager.PlayerPay(tableID, playerID, chips)
+	}
+
+	return nil, ErrServerUnknownWagerAction
+}
+
+func (s *Server) handleGamePlayerAutoMode(session *Session, params Params) (interface{}, error) {
+	competitionID, tableID, err := tableParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	isOn, err := params.Bool(2)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getTable(competitionID, tableID); err != nil {
+		return nil, err
+	}
+
+	// player is notified by the table event, so are changes made by table engine
+	return nil, s.manager.PlayerAutoMode(tableID, session.PlayerID(), isOn)
+}
+
+func (s *Server) getTable(competitionID, tableID string) (*pwbtable.Table, error) {
+	tableEngine, err := s.manager.GetTableEngine(tableID)
+	if err != nil {
+		return nil, ErrServerTableNotFound
+	}
+
+	table, err := tableEngine.GetTable().Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	if table.Meta.CompetitionID != competitionID {
+		return nil, ErrServerTableNotFound
+	}
+
+	return table, nil
+}
+
+func tableParams(params Params) (string, string, error) {
+	competitionID, err := params.String(0)
+	if err != nil {
+		return "", "", err
+	}
+
+	tableID, err := params.String(1)
+	if err != nil {
+		return "", "", err
+	}
+
+	return competitionID, tableID, nil
+}
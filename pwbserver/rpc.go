@@ -0,0 +1,114 @@
+package pwbserver
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+const (
+	JSONRPCVersion = "2.0"
+
+	// JSON-RPC 2.0 Error Codes
+	ErrorCode_ParseError     = -32700
+	ErrorCode_InvalidRequest = -32600
+	ErrorCode_MethodNotFound = -32601
+	ErrorCode_InvalidParams  = -32602
+	ErrorCode_InternalError  = -32603
+	ErrorCode_ServerError    = -32000
+	ErrorCode_Unauthorized   = -32001
+)
+
+var (
+	ErrServerInvalidParams = errors.New("server: invalid params")
+	ErrServerUnauthorized  = errors.New("server: unauthorized")
+)
+
+// Request keeps id as it is since it is either number, string or null, response echoes it back unchanged.
+type Request struct {
+	JSONRPC string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func NewError(code int, message string) *Error {
+	return &Error{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// notificationID is id of pushed notifications, which is how the Unity client tells notifications from responses.
+var notificationID = json.RawMessage("0")
+
+// Notification is pushed with id 0.
+type Notification struct {
+	EventName string      `json:"event_name"`
+	Event     interface{} `json:"event"`
+}
+
+type Params []json.RawMessage
+
+func (p Params) String(idx int) (string, error) {
+	if idx >= len(p) {
+		return "", ErrServerInvalidParams
+	}
+
+	var value string
+	if err := json.Unmarshal(p[idx], &value); err != nil {
+		return "", ErrServerInvalidParams
+	}
+	return value, nil
+}
+
+// Int64 accepts both number and numeric string since the client sends chips in either form.
+func (p Params) Int64(idx int) (int64, error) {
+	if idx >= len(p) {
+		return 0, ErrServerInvalidParams
+	}
+
+	var value int64
+	if err := json.Unmarshal(p[idx], &value); err == nil {
+		return value, nil
+	}
+
+	var str string
+	if err := json.Unmarshal(p[idx], &str); err != nil {
+		return 0, ErrServerInvalidParams
+	}
+
+	value, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return 0, ErrServerInvalidParams
+	}
+	return value, nil
+}
+
+func (p Params) Bool(idx int) (bool, error) {
+	if idx >= len(p) {
+		return false, ErrServerInvalidParams
+	}
+
+	var value bool
+	if err := json.Unmarshal(p[idx], &value); err != nil {
+		return false, ErrServerInvalidParams
+	}
+	return value, nil
+}
@@ -0,0 +1,308 @@
+package pwbserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+)
+
+const (
+	// Notification Event Name
+	EventName_TableUpdated           = "table_updated"
+	EventName_GameStateUpdated       = "game_state_updated"
+	EventName_AutoModeUpdated        = "game_player_auto_mode_updated"
+	EventName_PlayerNewDeviceUpdated = "system_player_new_device_updated"
+)
+
+const (
+	defaultSessionSendBufferSize = 256
+	defaultSessionReadTimeout    = 60 * time.Second
+	defaultSessionWriteTimeout   = 10 * time.Second
+	defaultSessionMaxMessageSize = 64 * 1024
+)
+
+type MethodHandler func(s *Session, params Params) (interface{}, error)
+
+type ServerOpt func(*Server)
+
+// Server exposes Manager to clients by JSON-RPC 2.0 over WebSocket.
+type Server struct {
+	manager       pwbtable.Manager
+	verifier      TokenVerifier
+	upgrader      websocket.Upgrader
+	methods       map[string]MethodHandler
+	publicMethods map[string]bool
+	mu            sync.RWMutex
+	sessions      map[string]*Session
+	players       map[string]*Session
+	competitions  map[string][]string
+}
+
+func NewServer(manager pwbtable.Manager, verifier TokenVerifier, opts ...ServerOpt) *Server {
+	s := &Server{
+		manager:       manager,
+		verifier:      verifier,
+		methods:       make(map[string]MethodHandler),
+		publicMethods: make(map[string]bool),
+		sessions:      make(map[string]*Session),
+		players:       make(map[string]*Session),
+		competitions:  make(map[string][]string),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.registerMethods()
+
+	return s
+}
+
+// WithCheckOrigin decides which origins are allowed to connect, only the same origin is allowed by default.
+func WithCheckOrigin(fn func(r *http.Request) bool) ServerOpt {
+	return func(s *Server) {
+		s.upgrader.CheckOrigin = fn
+	}
+}
+
+// Handle registers a method which requires an authenticated session.
+func (s *Server) Handle(method string, fn MethodHandler) {
+	s.methods[method] = fn
+}
+
+// HandlePublic registers a method which can be called before authentication.
+func (s *Server) HandlePublic(method string, fn MethodHandler) {
+	s.methods[method] = fn
+	s.publicMethods[method] = true
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	session := newSession(s, ws)
+
+	s.mu.Lock()
+	s.sessions[session.ID()] = session
+	s.mu.Unlock()
+
+	go session.writeLoop()
+	session.readLoop()
+}
+
+// CreateTable creates table by Manager and pushes its updates to subscribed sessions.
+func (s *Server) CreateTable(options *pwbtable.TableEngineOptions, setting pwbtable.TableSetting) (*pwbtable.Table, error) {
+	if options == nil {
+		options = pwbtable.NewTableEngineOptions()
+	}
+	options.EnablePlayerViews = true
+
+	table, err := s.manager.CreateTable(options, s.NewTableEngineCallbacks(), setting)
+	if err != nil {
+		return nil, err
+	}
+
+	tableEngine, err := s.manager.GetTableEngine(table.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.SubscribeTableEvents(tableEngine)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.competitions[table.Meta.CompetitionID] = append(s.competitions[table.Meta.CompetitionID], table.ID)
+
+	return table, nil
+}
+
+// SubscribeTableEvents pushes game state and auto mode updates of table to sessions, tables created by CreateTable are subscribed already.
+func (s *Server) SubscribeTableEvents(tableEngine pwbtable.TableEngine) pwbtable.TableEventSubscription {
+	competitionID := tableEngine.GetTable().Meta.CompetitionID
+	return tableEngine.SubscribeTableEventsFunc(nil, func(event *pwbtable.TableEvent) {
+		if event.Error != "" {
+			return
+		}
+
+		switch event.Kind {
+		case pwbtable.TableEventKind_GameStateUpdated:
+			s.broadcastGameState(tableEngine)
+		case pwbtable.TableEventKind_PlayerAutoModeUpdated:
+			isOn, _ := event.Payload.(bool)
+			s.sendToPlayer(event.PlayerID, EventName_AutoModeUpdated, AutoModeUpdated{
+				CompetitionID: competitionID,
+				TableID:       event.TableID,
+				IsOn:          isOn,
+			})
+		}
+	})
+}
+
+// NewTableEngineCallbacks is for tables which are not created by CreateTable but still pushed to sessions,
+// those tables should enable player views so that sessions receive redacted tables, and be subscribed by SubscribeTableEvents.
+func (s *Server) NewTableEngineCallbacks() *pwbtable.TableEngineCallbacks {
+	callbacks := pwbtable.NewTableEngineCallbacks()
+	callbacks.OnTableViewUpdated = func(playerID string, table *pwbtable.Table) {
+		s.broadcastTableView(playerID, table)
+	}
+	return callbacks
+}
+
+func (s *Server) handleRequest(session *Session, req *Request) *Response {
+	resp := &Response{
+		JSONRPC: JSONRPCVersion,
+		ID:      req.ID,
+	}
+
+	handler, exist := s.methods[req.Method]
+	if !exist {
+		resp.Error = NewError(ErrorCode_MethodNotFound, fmt.Sprintf("method not found: %s", req.Method))
+		return resp
+	}
+
+	if !s.publicMethods[req.Method] && session.PlayerID() == "" {
+		resp.Error = NewError(ErrorCode_Unauthorized, ErrServerUnauthorized.Error())
+		return resp
+	}
+
+	result, err := handler(session, Params(req.Params))
+	if err != nil {
+		resp.Error = toError(err)
+		return resp
+	}
+
+	if result == nil {
+		result = struct{}{}
+	}
+	resp.Result = result
+	return resp
+}
+
+func (s *Server) authenticate(session *Session, playerID string) {
+	s.mu.Lock()
+	prevSession, exist := s.players[playerID]
+	s.players[playerID] = session
+	s.mu.Unlock()
+
+	session.setPlayerID(playerID)
+
+	// same player is logged in on another device
+	if exist && prevSession != session {
+		prevSession.notify(EventName_PlayerNewDeviceUpdated, struct{}{})
+	}
+}
+
+func (s *Server) removeSession(session *Session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, session.ID())
+	if playerID := session.PlayerID(); playerID != "" && s.players[playerID] == session {
+		delete(s.players, playerID)
+	}
+}
+
+func (s *Server) competitionTableIDs(competitionID string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append(make([]string, 0), s.competitions[competitionID]...)
+}
+
+// broadcastTableView sends view of player to the player, or spectator view (empty playerID) to those who are not seated.
+func (s *Server) broadcastTableView(playerID string, table *pwbtable.Table) {
+	// encoded once at emitting time since table keeps changing afterwards
+	data, err := encodeNotification(EventName_TableUpdated, table)
+	if err != nil {
+		return
+	}
+
+	s.mu.RLock()
+	sessions := make([]*Session, 0)
+	for _, session := range s.sessions {
+		if !session.isSubscribed(table.ID) {
+			continue
+		}
+
+		sessionPlayerID := session.PlayerID()
+		if playerID != "" && sessionPlayerID != playerID {
+			continue
+		}
+		if playerID == "" && sessionPlayerID != "" && table.FindPlayerIdx(sessionPlayerID) != pwbtable.UnsetValue {
+			continue
+		}
+
+		sessions = append(sessions, session)
+	}
+	s.mu.RUnlock()
+
+	for _, session := range sessions {
+		session.send(data)
+	}
+}
+
+// broadcastGameState sends game state of the view of every subscribed session.
+func (s *Server) broadcastGameState(tableEngine pwbtable.TableEngine) {
+	table, err := tableEngine.GetTable().Clone()
+	if err != nil || table.State.GameState == nil {
+		return
+	}
+
+	s.mu.RLock()
+	sessions := make([]*Session, 0)
+	for _, session := range s.sessions {
+		if session.isSubscribed(table.ID) {
+			sessions = append(sessions, session)
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, session := range sessions {
+		view, err := table.ViewFor(session.PlayerID())
+		if err != nil {
+			continue
+		}
+		session.notify(EventName_GameStateUpdated, view.State.GameState)
+	}
+}
+
+func (s *Server) sendToPlayer(playerID string, eventName string, event interface{}) {
+	s.mu.RLock()
+	session, exist := s.players[playerID]
+	s.mu.RUnlock()
+
+	if exist {
+		session.notify(eventName, event)
+	}
+}
+
+func encodeNotification(eventName string, event interface{}) ([]byte, error) {
+	return json.Marshal(Response{
+		JSONRPC: JSONRPCVersion,
+		ID:      notificationID,
+		Result: Notification{
+			EventName: eventName,
+			Event:     event,
+		},
+	})
+}
+
+func toError(err error) *Error {
+	switch e := err.(type) {
+	case *Error:
+		return e
+	}
+
+	switch err {
+	case ErrServerInvalidParams:
+		return NewError(ErrorCode_InvalidParams, err.Error())
+	case ErrServerUnauthorized:
+		return NewError(ErrorCode_Unauthorized, err.Error())
+	}
+
+	return NewError(ErrorCode_ServerError, err.Error())
+}
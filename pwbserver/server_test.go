@@ -0,0 +1,224 @@
+package pwbserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+)
+
+type testClient struct {
+	t             *testing.T
+	ws            *websocket.Conn
+	nextID        int64
+	notifications []testMessage
+}
+
+type testMessage struct {
+	ID     int64           `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+}
+
+func (c *testClient) call(method string, params ...interface{}) testMessage {
+	c.nextID++
+	id := c.nextID
+	assert.Nil(c.t, c.ws.WriteJSON(map[string]interface{}{
+		"jsonrpc": JSONRPCVersion,
+		"id":      id,
+		"method":  method,
+		"params":  params,
+	}))
+
+	for {
+		msg := c.read()
+		if msg.ID == id {
+			return msg
+		}
+
+		if msg.ID == 0 {
+			c.notifications = append(c.notifications, msg)
+		}
+	}
+}
+
+func (c *testClient) waitNotification(eventName string) json.RawMessage {
+	for {
+		var msg testMessage
+		if len(c.notifications) > 0 {
+			msg, c.notifications = c.notifications[0], c.notifications[1:]
+		} else {
+			msg = c.read()
+		}
+
+		if msg.ID != 0 {
+			continue
+		}
+
+		var notification struct {
+			EventName string          `json:"event_name"`
+			Event     json.RawMessage `json:"event"`
+		}
+		assert.Nil(c.t, json.Unmarshal(msg.Result, &notification))
+		if notification.EventName == eventName {
+			return notification.Event
+		}
+	}
+}
+
+func (c *testClient) read() testMessage {
+	c.ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg testMessage
+	if !assert.Nil(c.t, c.ws.ReadJSON(&msg)) {
+		c.t.FailNow()
+	}
+	return msg
+}
+
+func TestServer_Match(t *testing.T) {
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	server := NewServer(manager, NewInsecureTokenVerifier())
+	table, err := server.CreateTable(nil, pwbtable.TableSetting{
+		TableID: "table-1",
+		Meta: pwbtable.TableMeta{
+			CompetitionID:       "competition-1",
+			Rule:                pwbtable.CompetitionRule_Default,
+			Mode:                pwbtable.CompetitionMode_Cash,
+			TableMaxSeatCount:   9,
+			TableMinPlayerCount: 2,
+			MinChipUnit:         10,
+			ActionTime:          10,
+		},
+	})
+	assert.Nil(t, err, "create table failed")
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	if !assert.Nil(t, err, "dial failed") {
+		return
+	}
+	defer ws.Close()
+	client := &testClient{t: t, ws: ws}
+
+	// public methods
+	resp := client.call("System.DeepPing", "1700000000000")
+	assert.Nil(t, resp.Error)
+	assert.Contains(t, string(resp.Result), `"client_timestamp":1700000000000`)
+
+	resp = client.call("Match.TableGetLatest", table.Meta.CompetitionID, table.ID)
+	if assert.NotNil(t, resp.Error) {
+		assert.Equal(t, ErrorCode_Unauthorized, resp.Error.Code)
+	}
+
+	resp = client.call("Unknown.Method")
+	if assert.NotNil(t, resp.Error) {
+		assert.Equal(t, ErrorCode_MethodNotFound, resp.Error.Code)
+	}
+
+	// authenticated methods
+	resp = client.call("Auth.Authenticate", "Fred")
+	assert.Nil(t, resp.Error)
+
+	resp = client.call("Match.TableGetLatest", table.Meta.CompetitionID, table.ID)
+	assert.Nil(t, resp.Error)
+	var latest struct {
+		Table pwbtable.Table `json:"table"`
+	}
+	assert.Nil(t, json.Unmarshal(resp.Result, &latest))
+	assert.Equal(t, table.ID, latest.Table.ID)
+
+	resp = client.call("Match.TableGetLatest", "other-competition", table.ID)
+	assert.NotNil(t, resp.Error)
+
+	resp = client.call("Match.CompetitionCashBuyIn", table.Meta.CompetitionID, "2000", "", "")
+	assert.Nil(t, resp.Error)
+
+	var updated pwbtable.Table
+	assert.Nil(t, json.Unmarshal(client.waitNotification(EventName_TableUpdated), &updated))
+	assert.Equal(t, table.ID, updated.ID)
+
+	resp = client.call("Match.TableJoin", table.Meta.CompetitionID, table.ID, "", "")
+	assert.Nil(t, resp.Error)
+
+	resp = client.call("Match.GamePlayerAutoMode", table.Meta.CompetitionID, table.ID, true)
+	assert.Nil(t, resp.Error)
+
+	var autoMode AutoModeUpdated
+	assert.Nil(t, json.Unmarshal(client.waitNotification(EventName_AutoModeUpdated), &autoMode))
+	assert.Equal(t, table.ID, autoMode.TableID)
+	assert.True(t, autoMode.IsOn)
+
+	resp = client.call("Match.GamePlayerWager", table.Meta.CompetitionID, table.ID, "dance", 0)
+	assert.NotNil(t, resp.Error)
+}
+
+func TestServer_CheckOrigin(t *testing.T) {
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	url := func(httpServer *httptest.Server) string {
+		return "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	}
+	crossOrigin := http.Header{"Origin": []string{"http://example.com"}}
+
+	// same origin only by default
+	httpServer := httptest.NewServer(NewServer(manager, NewInsecureTokenVerifier()))
+	defer httpServer.Close()
+
+	_, _, err := websocket.DefaultDialer.Dial(url(httpServer), crossOrigin)
+	assert.ErrorIs(t, err, websocket.ErrBadHandshake)
+
+	ws, _, err := websocket.DefaultDialer.Dial(url(httpServer), http.Header{"Origin": []string{httpServer.URL}})
+	if assert.Nil(t, err, "same origin dial failed") {
+		ws.Close()
+	}
+
+	// opted out
+	openServer := httptest.NewServer(NewServer(manager, NewInsecureTokenVerifier(), WithCheckOrigin(func(r *http.Request) bool { return true })))
+	defer openServer.Close()
+
+	ws, _, err = websocket.DefaultDialer.Dial(url(openServer), crossOrigin)
+	if assert.Nil(t, err, "cross origin dial failed") {
+		ws.Close()
+	}
+}
+
+func TestServer_RequestID(t *testing.T) {
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	httpServer := httptest.NewServer(NewServer(manager, NewInsecureTokenVerifier()))
+	defer httpServer.Close()
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	if !assert.Nil(t, err, "dial failed") {
+		return
+	}
+	defer ws.Close()
+
+	// id is echoed back unchanged
+	for _, id := range []string{`"request-1"`, `7`, `null`} {
+		assert.Nil(t, ws.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":`+id+`,"method":"System.DeepPing","params":["1700000000000"]}`)))
+
+		ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+		var resp struct {
+			ID     json.RawMessage `json:"id"`
+			Result json.RawMessage `json:"result"`
+		}
+		if !assert.Nil(t, ws.ReadJSON(&resp)) {
+			return
+		}
+		assert.Equal(t, id, string(resp.ID))
+		assert.NotEmpty(t, resp.Result)
+	}
+}
@@ -0,0 +1,151 @@
+package pwbserver
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// Session is a WebSocket connection of a client.
+type Session struct {
+	id        string
+	server    *Server
+	ws        *websocket.Conn
+	outgoing  chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+	mu        sync.RWMutex
+	playerID  string
+	tableIDs  map[string]bool
+}
+
+func newSession(server *Server, ws *websocket.Conn) *Session {
+	return &Session{
+		id:       uuid.New().String(),
+		server:   server,
+		ws:       ws,
+		outgoing: make(chan []byte, defaultSessionSendBufferSize),
+		done:     make(chan struct{}),
+		tableIDs: make(map[string]bool),
+	}
+}
+
+func (s *Session) ID() string {
+	return s.id
+}
+
+func (s *Session) PlayerID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.playerID
+}
+
+// Subscribe pushes updates of table to this session.
+func (s *Session) Subscribe(tableID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tableIDs[tableID] = true
+}
+
+func (s *Session) Unsubscribe(tableID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tableIDs, tableID)
+}
+
+func (s *Session) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.ws.Close()
+		s.server.removeSession(s)
+	})
+}
+
+func (s *Session) setPlayerID(playerID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.playerID = playerID
+}
+
+func (s *Session) isSubscribed(tableID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tableIDs[tableID]
+}
+
+func (s *Session) notify(eventName string, event interface{}) {
+	data, err := encodeNotification(eventName, event)
+	if err != nil {
+		return
+	}
+	s.send(data)
+}
+
+func (s *Session) send(data []byte) {
+	select {
+	case <-s.done:
+	case s.outgoing <- data:
+	default:
+		// slow client is disconnected instead of blocking tables
+		s.Close()
+	}
+}
+
+func (s *Session) readLoop() {
+	defer s.Close()
+
+	s.ws.SetReadLimit(defaultSessionMaxMessageSize)
+	for {
+		s.ws.SetReadDeadline(time.Now().Add(defaultSessionReadTimeout))
+		_, message, err := s.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var req Request
+		if err := json.Unmarshal(message, &req); err != nil {
+			s.reply(&Response{
+				JSONRPC: JSONRPCVersion,
+				Error:   NewError(ErrorCode_ParseError, err.Error()),
+			})
+			continue
+		}
+
+		if req.JSONRPC != JSONRPCVersion || req.Method == "" {
+			s.reply(&Response{
+				JSONRPC: JSONRPCVersion,
+				ID:      req.ID,
+				Error:   NewError(ErrorCode_InvalidRequest, "invalid request"),
+			})
+			continue
+		}
+
+		s.reply(s.server.handleRequest(s, &req))
+	}
+}
+
+func (s *Session) writeLoop() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case data := <-s.outgoing:
+			s.ws.SetWriteDeadline(time.Now().Add(defaultSessionWriteTimeout))
+			if err := s.ws.WriteMessage(websocket.TextMessage, data); err != nil {
+				s.Close()
+				return
+			}
+		}
+	}
+}
+
+func (s *Session) reply(resp *Response) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+	s.send(data)
+}
@@ -0,0 +1,98 @@
+package pwbtable
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/weedbox/pokerface"
+)
+
+func (te *tableEngine) refreshActionTimer(gs *pokerface.GameState) {
+	if te.table.Meta.ActionTime <= 0 {
+		return
+	}
+
+	// only a player who is able to wager should be timed
+	if gs.Status.CurrentEvent != pokerface.GameEventSymbols[pokerface.GameEvent_RoundStarted] {
+		te.stopActionTimer()
+		return
+	}
+
+	gamePlayerIdx := gs.Status.CurrentPlayer
+	player := gs.GetPlayer(gamePlayerIdx)
+	if player == nil || len(player.AllowedActions) == 0 || gamePlayerIdx >= len(te.table.State.GamePlayerIndexes) {
+		te.stopActionTimer()
+		return
+	}
+
+	// keep counting down if current player is not changed
+	key := fmt.Sprintf("%s/%s/%d", gs.GameID, gs.Status.Round, gamePlayerIdx)
+	if key == te.actionTimerKey {
+		return
+	}
+	te.actionTimerKey = key
+
+	playerIdx := te.table.State.GamePlayerIndexes[gamePlayerIdx]
+	playerID := te.table.State.PlayerStates[playerIdx].PlayerID
+	duration := time.Duration(te.table.Meta.ActionTime) * time.Second
+
+	// restored player keeps the deadline before the table was restored
+	if te.restoredActionEndAt > 0 {
+		if remaining := time.Until(time.Unix(te.restoredActionEndAt, 0)); remaining > 0 && remaining < duration {
+			duration = remaining
+		}
+		te.restoredActionEndAt = 0
+	}
+	te.table.State.ActionEndAt = time.Now().Add(duration).Unix()
+
+	te.tb.NewTask(duration, func(isCancelled bool) {
+		if isCancelled {
+			return
+		}
+
+		te.onActionTimeout(key, playerID)
+	})
+}
+
+func (te *tableEngine) stopActionTimer() {
+	te.tb.Cancel()
+	te.actionTimerKey = ""
+	te.restoredActionEndAt = 0
+	te.table.State.ActionEndAt = UnsetValue
+}
+
+func (te *tableEngine) onActionTimeout(key, playerID string) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if key != te.actionTimerKey {
+		return
+	}
+
+	gs := te.table.State.GameState
+	gamePlayerIdx := te.table.FindGamePlayerIdx(playerID)
+	if gs == nil || gamePlayerIdx == UnsetValue || gs.Status.CurrentPlayer != gamePlayerIdx {
+		return
+	}
+
+	// the action made for player resets timeout count
+	timeoutCount := te.table.State.PlayerStates[te.table.State.GamePlayerIndexes[gamePlayerIdx]].TimeoutCount + 1
+
+	// check if allowed, otherwise fold
+	if gs.HasAction(gamePlayerIdx, WagerAction_Check) {
+		if err := te.playerCheck(playerID); err != nil {
+			te.emitErrorEvent(TableEventKind_PlayerActionTimeout, playerID, err)
+			return
+		}
+		te.countActionTimeout(playerID, timeoutCount)
+		te.emitEvent(TableEventKind_PlayerActionTimeout, playerID, WagerAction_Check)
+		return
+	}
+
+	if err := te.playerFold(playerID); err != nil {
+		te.emitErrorEvent(TableEventKind_PlayerActionTimeout, playerID, err)
+		return
+	}
+	te.countActionTimeout(playerID, timeoutCount)
+	te.emitEvent(TableEventKind_PlayerActionTimeout, playerID, WagerAction_Fold)
+}
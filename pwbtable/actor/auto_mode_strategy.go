@@ -0,0 +1,32 @@
+package actor
+
+import (
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+)
+
+type botAutoModeStrategy struct {
+	rand pwbtable.RandSource
+}
+
+// NewBotAutoModeStrategy plays for players in auto mode with the same random moves as bot runner.
+func NewBotAutoModeStrategy(rs pwbtable.RandSource) pwbtable.AutoModeStrategy {
+	if rs == nil {
+		rs = pwbtable.NewCryptoRandSource()
+	}
+
+	return &botAutoModeStrategy{
+		rand: rs,
+	}
+}
+
+func (s *botAutoModeStrategy) RequestMove(te pwbtable.TableEngine, table *pwbtable.Table, playerID string) error {
+	br := NewBotRunner(playerID)
+	br.SetRandSource(s.rand)
+	br.tableInfo = table
+
+	a := NewActor()
+	a.SetAdapter(NewTableEngineAdapter(te, table))
+	a.SetRunner(br)
+
+	return br.requestAI(table.State.GameState, table.GamePlayerIndex(playerID))
+}
@@ -1,7 +1,6 @@
 package actor
 
 import (
-	"math/rand"
 	"time"
 
 	"github.com/weedbox/PokerWeedBox/pwbtable"
@@ -35,6 +34,7 @@ type botRunner struct {
 	curGameID                      string
 	lastGameStateTime              int64
 	timebank                       *timebank.TimeBank
+	rand                           pwbtable.RandSource
 	tableInfo                      *pwbtable.Table
 	onTableAutoJoinActionRequested TableAutoJoinActionRequestFunc
 }
@@ -43,6 +43,7 @@ func NewBotRunner(playerID string) *botRunner {
 	return &botRunner{
 		playerID:                       playerID,
 		timebank:                       timebank.NewTimeBank(),
+		rand:                           pwbtable.NewCryptoRandSource(),
 		onTableAutoJoinActionRequested: func(string, string, string) {},
 	}
 }
@@ -52,6 +53,10 @@ func (br *botRunner) SetActor(a Actor) {
 	br.actions = NewActions(a, br.playerID)
 }
 
+func (br *botRunner) SetRandSource(rs pwbtable.RandSource) {
+	br.rand = rs
+}
+
 func (br *botRunner) Humanized(enabled bool) {
 	br.isHumanized = enabled
 }
@@ -173,7 +178,7 @@ func (br *botRunner) requestMove(gs *pokerface.GameState, playerIdx int) error {
 	}
 
 	// For simulating human-like behavior, to incorporate random delays when performing actions.
-	thinkingTime := rand.Intn(br.tableInfo.Meta.ActionTime)
+	thinkingTime := br.rand.Intn(br.tableInfo.Meta.ActionTime)
 	if thinkingTime == 0 {
 		return br.requestAI(gs, playerIdx)
 	}
@@ -217,10 +222,8 @@ func (br *botRunner) calcActionProbabilities(actions []string) map[string]float6
 func (br *botRunner) calcAction(actions []string) string {
 
 	// Select action randomly
-	rand.Seed(time.Now().UnixNano())
-
 	probabilities := br.calcActionProbabilities(actions)
-	randomNum := rand.Float64()
+	randomNum := br.rand.Float64()
 
 	for action, probability := range probabilities {
 		if randomNum < probability {
@@ -252,13 +255,19 @@ func (br *botRunner) requestAI(gs *pokerface.GameState, playerIdx int) error {
 	switch action {
 	case "bet":
 
+		// fixed limit only accepts the bet size of round
+		if br.isFixedLimit() {
+			return br.actions.Bet(br.tableInfo.FixedLimitChipLevel(gs) - player.Wager)
+		}
+
 		minBet := gs.Status.MiniBet
+		maxBet := br.maxChipLevel(gs, playerIdx) - player.Wager
 
-		if player.InitialStackSize <= minBet {
-			return br.actions.Bet(player.InitialStackSize)
+		if maxBet <= minBet {
+			return br.actions.Bet(maxBet)
 		}
 
-		chips = rand.Int63n(player.InitialStackSize-minBet) + minBet
+		chips = br.rand.Int63n(maxBet-minBet) + minBet
 
 		err := br.actions.Bet(chips)
 		if err != nil {
@@ -268,7 +277,11 @@ func (br *botRunner) requestAI(gs *pokerface.GameState, playerIdx int) error {
 		return nil
 	case "raise":
 
-		maxChipLevel := player.InitialStackSize
+		if br.isFixedLimit() {
+			return br.actions.Raise(br.tableInfo.FixedLimitChipLevel(gs))
+		}
+
+		maxChipLevel := br.maxChipLevel(gs, playerIdx)
 		minChipLevel := gs.Status.CurrentWager + gs.Status.PreviousRaiseSize
 
 		if maxChipLevel <= minChipLevel {
@@ -280,7 +293,7 @@ func (br *botRunner) requestAI(gs *pokerface.GameState, playerIdx int) error {
 			return nil
 		}
 
-		chips = rand.Int63n(maxChipLevel-minChipLevel) + minChipLevel
+		chips = br.rand.Int63n(maxChipLevel-minChipLevel) + minChipLevel
 
 		err := br.actions.Raise(chips)
 		if err != nil {
@@ -318,3 +331,20 @@ func (br *botRunner) requestAI(gs *pokerface.GameState, playerIdx int) error {
 
 	return nil
 }
+
+// maxChipLevel keeps random wager within betting limit of table.
+func (br *botRunner) maxChipLevel(gs *pokerface.GameState, playerIdx int) int64 {
+	maxChipLevel := gs.Players[playerIdx].InitialStackSize
+	if br.tableInfo == nil || br.tableInfo.Meta.BettingLimit() != pwbtable.BettingLimit_PotLimit {
+		return maxChipLevel
+	}
+
+	if potLimit := pwbtable.PotLimitChipLevel(gs, playerIdx); potLimit < maxChipLevel {
+		return potLimit
+	}
+	return maxChipLevel
+}
+
+func (br *botRunner) isFixedLimit() bool {
+	return br.tableInfo != nil && br.tableInfo.Meta.BettingLimit() == pwbtable.BettingLimit_FixedLimit
+}
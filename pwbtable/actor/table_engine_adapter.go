@@ -90,6 +90,11 @@ func (tea *tableEngineAdapter) Raise(playerID string, chipLevel int64) error {
 }
 
 func (tea *tableEngineAdapter) ExtendTime(playerID string, duration time.Duration) error {
-	//TODO: need to be implemented
-	return nil
+	// time bank is counted in seconds, partial second is rounded up
+	seconds := int(duration / time.Second)
+	if duration%time.Second > 0 {
+		seconds++
+	}
+
+	return tea.engine.PlayerExtendTime(playerID, seconds)
 }
@@ -0,0 +1,153 @@
+package pwbtable
+
+import (
+	"github.com/weedbox/pokerface"
+)
+
+// AutoModeStrategy makes wager moves for players in auto mode, ready and pay are made by table engine.
+type AutoModeStrategy interface {
+	RequestMove(te TableEngine, table *Table, playerID string) error
+}
+
+type checkOrFoldStrategy struct{}
+
+// NewCheckOrFoldStrategy checks if allowed, otherwise folds.
+func NewCheckOrFoldStrategy() AutoModeStrategy {
+	return &checkOrFoldStrategy{}
+}
+
+func (s *checkOrFoldStrategy) RequestMove(te TableEngine, table *Table, playerID string) error {
+	gs := table.State.GameState
+	if gs.HasAction(table.GamePlayerIndex(playerID), WagerAction_Check) {
+		return te.PlayerCheck(playerID)
+	}
+	return te.PlayerFold(playerID)
+}
+
+// autoModePlayer is a player in auto mode who is dealt in, it is copied under table lock before moves are requested.
+type autoModePlayer struct {
+	gamePlayerIdx int
+	playerID      string
+}
+
+func (te *tableEngine) PlayerAutoMode(playerID string, isOn bool) error {
+	gs, err := te.updatePlayerAutoMode(playerID, isOn)
+	if err != nil {
+		return err
+	}
+
+	// player may be waited for already
+	if isOn && gs != nil {
+		te.runAutoMode(gs)
+	}
+
+	return nil
+}
+
+// updatePlayerAutoMode returns game state of the table when mode is updated.
+func (te *tableEngine) updatePlayerAutoMode(playerID string, isOn bool) (*pokerface.GameState, error) {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	playerIdx := te.table.FindPlayerIdx(playerID)
+	if playerIdx == UnsetValue {
+		return nil, ErrTablePlayerNotFound
+	}
+
+	playerState := te.table.State.PlayerStates[playerIdx]
+	if playerState.IsAutoMode == isOn {
+		return nil, nil
+	}
+
+	playerState.IsAutoMode = isOn
+	te.emitTablePlayerStateEvent(playerState)
+	te.emitEvent(TableEventKind_PlayerAutoModeUpdated, playerID, isOn)
+
+	return te.table.State.GameState, nil
+}
+
+// runAutoMode makes moves for players in auto mode who are requested by game state.
+func (te *tableEngine) runAutoMode(gs *pokerface.GameState) {
+	// moves take table lock by themselves
+	players := te.autoModePlayers()
+	for _, player := range players {
+		if err := te.requestAutoMove(gs, player.gamePlayerIdx, player.playerID); err != nil {
+			te.emitErrorEvent(TableEventKind_PlayerAutoModeUpdated, player.playerID, err)
+		}
+	}
+}
+
+func (te *tableEngine) autoModePlayers() []autoModePlayer {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if te.table.State.Status != TableStateStatus_TableGamePlaying {
+		return nil
+	}
+
+	players := make([]autoModePlayer, 0)
+	for gamePlayerIdx, playerIdx := range te.table.State.GamePlayerIndexes {
+		if playerIdx >= len(te.table.State.PlayerStates) {
+			continue
+		}
+
+		playerState := te.table.State.PlayerStates[playerIdx]
+		if !playerState.IsAutoMode {
+			continue
+		}
+
+		players = append(players, autoModePlayer{
+			gamePlayerIdx: gamePlayerIdx,
+			playerID:      playerState.PlayerID,
+		})
+	}
+	return players
+}
+
+func (te *tableEngine) requestAutoMove(gs *pokerface.GameState, gamePlayerIdx int, playerID string) error {
+	switch {
+	case gs.HasAction(gamePlayerIdx, Action_Ready):
+		return te.PlayerReady(playerID)
+	case gs.HasAction(gamePlayerIdx, Action_Pay):
+		return te.PlayerPay(playerID, autoPayChips(gs, gamePlayerIdx))
+	}
+
+	if gs.Status.CurrentEvent != pokerface.GameEventSymbols[pokerface.GameEvent_RoundStarted] || gs.Status.CurrentPlayer != gamePlayerIdx {
+		return nil
+	}
+
+	player := gs.GetPlayer(gamePlayerIdx)
+	if player == nil || len(player.AllowedActions) == 0 {
+		return nil
+	}
+
+	if gs.HasAction(gamePlayerIdx, "pass") {
+		return te.PlayerPass(playerID)
+	}
+
+	te.lock.Lock()
+	table, err := te.table.Clone()
+	te.lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if te.options != nil && te.options.AutoModeStrategy != nil {
+		return te.options.AutoModeStrategy.RequestMove(te, table, playerID)
+	}
+	return NewCheckOrFoldStrategy().RequestMove(te, table, playerID)
+}
+
+func autoPayChips(gs *pokerface.GameState, gamePlayerIdx int) int64 {
+	if gs.Status.CurrentEvent == pokerface.GameEventSymbols[pokerface.GameEvent_AnteRequested] {
+		return gs.Meta.Ante
+	}
+
+	switch {
+	case gs.HasPosition(gamePlayerIdx, Position_BB):
+		return gs.Meta.Blind.BB
+	case gs.HasPosition(gamePlayerIdx, Position_SB):
+		return gs.Meta.Blind.SB
+	}
+	return gs.Meta.Blind.Dealer
+}
@@ -0,0 +1,165 @@
+package pwbtable
+
+import (
+	"time"
+)
+
+type TableBlindStructure struct {
+	Levels []TableBlindLevel `json:"levels"`
+}
+
+type TableBlindLevel struct {
+	Level    int   `json:"level"`
+	Ante     int64 `json:"ante"`
+	Dealer   int64 `json:"dealer"`
+	SB       int64 `json:"sb"`
+	BB       int64 `json:"bb"`
+	SmallBet int64 `json:"small_bet"` // fixed limit only, big blind if 0
+	BigBet   int64 `json:"big_bet"`   // fixed limit only, double big blind if 0
+	Duration int   `json:"duration"`  // seconds, 0 means no time limit
+	Hands    int   `json:"hands"`     // 0 means no hand limit
+	IsBreak  bool  `json:"is_break"`
+}
+
+func (bs TableBlindStructure) IsEnabled() bool {
+	return len(bs.Levels) > 0
+}
+
+func (bs TableBlindStructure) Validate() error {
+	for idx, level := range bs.Levels {
+		// break is only ended by time since no hand is played
+		if level.IsBreak {
+			if level.Duration <= 0 {
+				return ErrTableInvalidBlindStructure
+			}
+			continue
+		}
+
+		if level.BB <= 0 || level.SB < 0 || level.Ante < 0 || level.Dealer < 0 || level.SmallBet < 0 || level.BigBet < 0 {
+			return ErrTableInvalidBlindStructure
+		}
+
+		// every level except the last one must be able to end
+		if idx < len(bs.Levels)-1 && level.Duration <= 0 && level.Hands <= 0 {
+			return ErrTableInvalidBlindStructure
+		}
+	}
+	return nil
+}
+
+func (te *tableEngine) startBlindStructure() {
+	if !te.table.Meta.BlindStructure.IsEnabled() {
+		return
+	}
+
+	te.applyBlindLevel(0, time.Now().Unix())
+}
+
+// refreshBlindLevel advances blind levels which are due, it should only be called between hands.
+func (te *tableEngine) refreshBlindLevel() {
+	structure := te.table.Meta.BlindStructure
+	if !structure.IsEnabled() {
+		return
+	}
+
+	bs := te.table.State.BlindState
+	now := time.Now().Unix()
+	for bs.LevelIndex < len(structure.Levels)-1 {
+		isTimeUp := bs.NextLevelAt != UnsetValue && now >= bs.NextLevelAt
+		isHandsPlayed := bs.NextLevelGameCount != UnsetValue && te.table.State.GameCount >= bs.NextLevelGameCount
+		if !isTimeUp && !isHandsPlayed {
+			return
+		}
+
+		// keep schedule on the clock even if levels are applied late
+		levelStartAt := now
+		if isTimeUp {
+			levelStartAt = bs.NextLevelAt
+		}
+		te.applyBlindLevel(bs.LevelIndex+1, levelStartAt)
+	}
+}
+
+func (te *tableEngine) applyBlindLevel(levelIdx int, levelStartAt int64) {
+	levels := te.table.Meta.BlindStructure.Levels
+	level := levels[levelIdx]
+
+	bs := te.table.State.BlindState
+	bs.LevelIndex = levelIdx
+	bs.Level = level.Level
+	if level.IsBreak {
+		bs.Level = -1
+	} else {
+		bs.Ante = level.Ante
+		bs.Dealer = level.Dealer
+		bs.SB = level.SB
+		bs.BB = level.BB
+		bs.SmallBet = level.SmallBet
+		bs.BigBet = level.BigBet
+	}
+
+	bs.NextLevelAt = UnsetValue
+	bs.NextLevelGameCount = UnsetValue
+	if levelIdx < len(levels)-1 {
+		if level.Duration > 0 {
+			bs.NextLevelAt = levelStartAt + int64(level.Duration)
+		}
+		if level.Hands > 0 {
+			bs.NextLevelGameCount = te.table.State.GameCount + level.Hands
+		}
+	}
+
+	te.emitEvent(TableEventKind_BlindLevelUpdated, "", *bs)
+	te.scheduleBreakEnd()
+}
+
+// scheduleBreakEnd resumes paused table once the break level is over.
+func (te *tableEngine) scheduleBreakEnd() {
+	te.stopBreakTimer()
+
+	bs := te.table.State.BlindState
+	if !bs.IsBreaking() || bs.NextLevelAt == UnsetValue {
+		return
+	}
+
+	te.breakTimer = time.AfterFunc(time.Until(time.Unix(bs.NextLevelAt, 0)), te.onBreakEnded)
+}
+
+func (te *tableEngine) stopBreakTimer() {
+	if te.breakTimer != nil {
+		te.breakTimer.Stop()
+		te.breakTimer = nil
+	}
+}
+
+// onBreakEnded is called by break timer, table is locked as player actions do while its state is checked,
+// game is opened after the lock is released since opening game retries for a while.
+func (te *tableEngine) onBreakEnded() {
+	if !te.resumeFromBreak() {
+		return
+	}
+
+	if err := te.TableGameOpen(); err != nil {
+		te.emitErrorEvent(TableEventKind_BlindLevelUpdated, "", err)
+	}
+}
+
+// resumeFromBreak refreshes blind level and returns whether paused table is ready to open game.
+func (te *tableEngine) resumeFromBreak() bool {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if te.table.State.Status == TableStateStatus_TableClosed {
+		return false
+	}
+
+	te.refreshBlindLevel()
+
+	if te.table.State.Status != TableStateStatus_TablePausing || te.table.ShouldPause() || te.table.State.StartAt == UnsetValue {
+		return false
+	}
+
+	// no other path resumes the table meanwhile
+	te.table.State.Status = TableStateStatus_TableGameStandby
+	return true
+}
@@ -0,0 +1,157 @@
+package pwbtable
+
+import (
+	"time"
+
+	"github.com/thoas/go-funk"
+)
+
+type TableCashSetting struct {
+	MinBuyIn      int64 `json:"min_buy_in"`      // 0 means no minimum
+	MaxBuyIn      int64 `json:"max_buy_in"`      // 0 means no maximum
+	IsInBB        bool  `json:"is_in_bb"`        // buy-in limits are counted in big blinds instead of chips
+	RatHolingTime int   `json:"rat_holing_time"` // seconds, player who returns within it must bring back the leaving stack, 0 disables
+}
+
+type TableLeftPlayer struct {
+	PlayerID string `json:"player_id"`
+	Bankroll int64  `json:"bankroll"`
+	LeftAt   int64  `json:"left_at"`
+}
+
+func (s TableCashSetting) Validate() error {
+	if s.MinBuyIn < 0 || s.MaxBuyIn < 0 || s.RatHolingTime < 0 {
+		return ErrTableInvalidCreateSetting
+	}
+
+	if s.MaxBuyIn > 0 && s.MinBuyIn > s.MaxBuyIn {
+		return ErrTableInvalidCreateSetting
+	}
+
+	return nil
+}
+
+// CashBuyInLimits returns buy-in limits in chips, limits in big blinds are ignored until blinds are set.
+func (t Table) CashBuyInLimits() (int64, int64) {
+	setting := t.Meta.Cash
+	if !setting.IsInBB {
+		return setting.MinBuyIn, setting.MaxBuyIn
+	}
+
+	bb := t.State.BlindState.BB
+	if bb <= 0 {
+		return 0, 0
+	}
+	return setting.MinBuyIn * bb, setting.MaxBuyIn * bb
+}
+
+func (t Table) FindLeftPlayer(playerID string, now int64) *TableLeftPlayer {
+	for _, player := range t.State.LeftPlayers {
+		if player.PlayerID == playerID && now < player.LeftAt+int64(t.Meta.Cash.RatHolingTime) {
+			return player
+		}
+	}
+	return nil
+}
+
+func (te *tableEngine) validateCashBuyIn(joinPlayer JoinPlayer) error {
+	if te.table.Meta.Mode != CompetitionMode_Cash {
+		return nil
+	}
+
+	if joinPlayer.RedeemChips <= 0 {
+		return ErrTableInvalidBuyIn
+	}
+
+	minBuyIn, maxBuyIn := te.table.CashBuyInLimits()
+
+	// rat-holing: chips taken off the table are brought back
+	if leftPlayer := te.table.FindLeftPlayer(joinPlayer.PlayerID, time.Now().Unix()); leftPlayer != nil {
+		if leftPlayer.Bankroll > minBuyIn {
+			minBuyIn = leftPlayer.Bankroll
+		}
+		if maxBuyIn > 0 && leftPlayer.Bankroll > maxBuyIn {
+			maxBuyIn = leftPlayer.Bankroll
+		}
+	}
+
+	if joinPlayer.RedeemChips < minBuyIn {
+		return ErrTableBuyInTooSmall
+	}
+
+	if maxBuyIn > 0 && joinPlayer.RedeemChips > maxBuyIn {
+		return ErrTableBuyInTooLarge
+	}
+
+	return nil
+}
+
+func (te *tableEngine) validateCashTopUp(playerState *TablePlayerState, chips int64) error {
+	if te.table.Meta.Mode != CompetitionMode_Cash {
+		return nil
+	}
+
+	if chips <= 0 {
+		return ErrTableInvalidBuyIn
+	}
+
+	// busted player buys in again
+	minBuyIn, maxBuyIn := te.table.CashBuyInLimits()
+	if playerState.Bankroll+playerState.PendingTopUp == 0 && chips < minBuyIn {
+		return ErrTableBuyInTooSmall
+	}
+
+	if maxBuyIn > 0 && playerState.Bankroll+playerState.PendingTopUp+chips > maxBuyIn {
+		return ErrTableBuyInTooLarge
+	}
+
+	return nil
+}
+
+// topUp adds chips to player, chips bought during the hand are added once the hand is settled.
+func (te *tableEngine) topUp(playerState *TablePlayerState, chips int64) {
+	if te.isPlayerInHand(playerState) {
+		playerState.PendingTopUp += chips
+		return
+	}
+	playerState.Bankroll += chips
+}
+
+func (te *tableEngine) isPlayerInHand(playerState *TablePlayerState) bool {
+	if !playerState.IsParticipated {
+		return false
+	}
+	return te.table.State.Status == TableStateStatus_TableGameOpened || te.table.State.Status == TableStateStatus_TableGamePlaying
+}
+
+// recordLeftPlayers keeps leaving stacks of cash game players for rat-holing rule.
+func (te *tableEngine) recordLeftPlayers(playerIDs []string) {
+	if te.table.Meta.Mode != CompetitionMode_Cash || te.table.Meta.Cash.RatHolingTime <= 0 {
+		return
+	}
+
+	now := time.Now().Unix()
+	leftPlayers := make([]*TableLeftPlayer, 0, len(te.table.State.LeftPlayers)+len(playerIDs))
+	for _, player := range te.table.State.LeftPlayers {
+		isExpired := now >= player.LeftAt+int64(te.table.Meta.Cash.RatHolingTime)
+		if !isExpired && !funk.ContainsString(playerIDs, player.PlayerID) {
+			leftPlayers = append(leftPlayers, player)
+		}
+	}
+
+	for _, playerID := range playerIDs {
+		playerIdx := te.table.FindPlayerIdx(playerID)
+		if playerIdx == UnsetValue {
+			continue
+		}
+
+		playerState := te.table.State.PlayerStates[playerIdx]
+		leftPlayers = append(leftPlayers, &TableLeftPlayer{
+			PlayerID: playerID,
+			Bankroll: playerState.Bankroll + playerState.PendingTopUp,
+			LeftAt:   now,
+		})
+	}
+
+	te.table.State.LeftPlayers = leftPlayers
+}
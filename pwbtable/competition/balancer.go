@@ -0,0 +1,258 @@
+package competition
+
+import (
+	"sort"
+
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+)
+
+type TablePlayerCount struct {
+	TableID     string `json:"table_id"`
+	PlayerCount int    `json:"player_count"`
+}
+
+type TableMove struct {
+	FromTableID string `json:"from_table_id"`
+	ToTableID   string `json:"to_table_id"`
+	PlayerCount int    `json:"player_count"`
+}
+
+// CalcTableMoves breaks the shortest tables when players fit in fewer tables, then keeps table sizes within one seat of each other.
+func CalcTableMoves(tables []TablePlayerCount, maxSeatCount int) []TableMove {
+	moves := make([]TableMove, 0)
+	if len(tables) <= 1 || maxSeatCount <= 0 {
+		return moves
+	}
+
+	sortedTables := append(make([]TablePlayerCount, 0, len(tables)), tables...)
+	sort.SliceStable(sortedTables, func(i, j int) bool {
+		return sortedTables[i].PlayerCount > sortedTables[j].PlayerCount
+	})
+
+	totalPlayerCount := 0
+	for _, table := range sortedTables {
+		totalPlayerCount += table.PlayerCount
+	}
+
+	tableCount := (totalPlayerCount + maxSeatCount - 1) / maxSeatCount
+	if tableCount < 1 {
+		tableCount = 1
+	}
+	if tableCount > len(sortedTables) {
+		tableCount = len(sortedTables)
+	}
+
+	// larger tables keep one more player so that less players are moved
+	surpluses := make([]TablePlayerCount, 0)
+	deficits := make([]TablePlayerCount, 0)
+	basePlayerCount := totalPlayerCount / tableCount
+	extraPlayerCount := totalPlayerCount % tableCount
+	for idx, table := range sortedTables {
+		target := 0
+		if idx < tableCount {
+			target = basePlayerCount
+			if idx < extraPlayerCount {
+				target++
+			}
+		}
+
+		if table.PlayerCount > target {
+			surpluses = append(surpluses, TablePlayerCount{TableID: table.TableID, PlayerCount: table.PlayerCount - target})
+		} else if table.PlayerCount < target {
+			deficits = append(deficits, TablePlayerCount{TableID: table.TableID, PlayerCount: target - table.PlayerCount})
+		}
+	}
+
+	for _, surplus := range surpluses {
+		for i := range deficits {
+			if surplus.PlayerCount == 0 {
+				break
+			}
+
+			deficit := &deficits[i]
+			if deficit.PlayerCount == 0 {
+				continue
+			}
+
+			playerCount := surplus.PlayerCount
+			if deficit.PlayerCount < playerCount {
+				playerCount = deficit.PlayerCount
+			}
+			surplus.PlayerCount -= playerCount
+			deficit.PlayerCount -= playerCount
+
+			moves = append(moves, TableMove{
+				FromTableID: surplus.TableID,
+				ToTableID:   deficit.TableID,
+				PlayerCount: playerCount,
+			})
+		}
+	}
+
+	return moves
+}
+
+// SelectMovingPlayers picks players who are about to be big blind first.
+func SelectMovingPlayers(table *pwbtable.Table, count int) []*pwbtable.TablePlayerState {
+	players := table.AlivePlayers()
+	maxSeatCount := table.Meta.TableMaxSeatCount
+	bbSeat := table.State.CurrentBBSeat
+
+	distance := func(seat int) int {
+		if bbSeat == pwbtable.UnsetValue {
+			return seat
+		}
+		return (seat - bbSeat - 1 + maxSeatCount) % maxSeatCount
+	}
+	sort.SliceStable(players, func(i, j int) bool {
+		return distance(players[i].Seat) < distance(players[j].Seat)
+	})
+
+	if count > len(players) {
+		count = len(players)
+	}
+	return players[:count]
+}
+
+// balanceTables moves players of the table which is between hands, or of tables which are waiting for players.
+func (c *competition) balanceTables(standbyTableID string) {
+	c.balanceMu.Lock()
+	defer c.balanceMu.Unlock()
+
+	c.mu.Lock()
+	status := c.state.Status
+	tableIDs := append(make([]string, 0), c.state.TableIDs...)
+	c.mu.Unlock()
+
+	if status != CompetitionStatus_Playing {
+		return
+	}
+
+	engines := make(map[string]pwbtable.TableEngine)
+	tables := make(map[string]*pwbtable.Table)
+	playerCounts := make([]TablePlayerCount, 0, len(tableIDs))
+	for _, tableID := range tableIDs {
+		tableEngine, err := c.manager.GetTableEngine(tableID)
+		if err != nil {
+			continue
+		}
+
+		table, err := tableEngine.GetTable().Clone()
+		if err != nil {
+			continue
+		}
+
+		engines[tableID] = tableEngine
+		tables[tableID] = table
+		playerCounts = append(playerCounts, TablePlayerCount{
+			TableID:     tableID,
+			PlayerCount: len(table.AlivePlayers()),
+		})
+	}
+
+	movedTableIDs := make(map[string]bool)
+	for _, move := range CalcTableMoves(playerCounts, c.setting.TableMeta.TableMaxSeatCount) {
+		// players are only moved between hands, others are moved when their tables are between hands
+		source := tables[move.FromTableID]
+		if move.FromTableID != standbyTableID && source.State.Status != pwbtable.TableStateStatus_TablePausing {
+			continue
+		}
+
+		players := SelectMovingPlayers(source, move.PlayerCount)
+		if err := c.movePlayers(engines[move.FromTableID], engines[move.ToTableID], players); err != nil {
+			c.emitCompetitionErrorUpdated(err)
+			continue
+		}
+
+		// refresh seats for the next move from the same table
+		if table, err := engines[move.FromTableID].GetTable().Clone(); err == nil {
+			tables[move.FromTableID] = table
+		}
+		movedTableIDs[move.FromTableID] = true
+		movedTableIDs[move.ToTableID] = true
+	}
+
+	if len(movedTableIDs) == 0 {
+		return
+	}
+
+	for tableID := range movedTableIDs {
+		tableEngine := engines[tableID]
+		table := tableEngine.GetTable()
+
+		// table is broken
+		if len(table.AlivePlayers()) == 0 {
+			if err := c.manager.CloseTable(tableID); err != nil {
+				c.emitCompetitionErrorUpdated(err)
+				continue
+			}
+
+			c.mu.Lock()
+			c.removeTableID(tableID)
+			c.mu.Unlock()
+			continue
+		}
+
+		// table was waiting for players
+		if tableID != standbyTableID && table.State.Status == pwbtable.TableStateStatus_TablePausing && !table.ShouldPause() {
+			go func(tableEngine pwbtable.TableEngine) {
+				if err := tableEngine.TableGameOpen(); err != nil {
+					c.emitCompetitionErrorUpdated(err)
+				}
+			}(tableEngine)
+		}
+	}
+
+	c.emitCompetitionUpdated()
+}
+
+// movePlayers seats players at target table with their bankroll, players stay at source table if any of them is not able to be seated.
+func (c *competition) movePlayers(source, target pwbtable.TableEngine, players []*pwbtable.TablePlayerState) error {
+	if len(players) == 0 {
+		return nil
+	}
+
+	// reserve seats at target before players leave source
+	sourceTable := source.GetTable()
+	playerIDs := make([]string, 0, len(players))
+	for _, player := range players {
+		joinPlayer := pwbtable.JoinPlayer{
+			PlayerID:    player.PlayerID,
+			RedeemChips: player.Bankroll,
+			Seat:        pwbtable.UnsetValue,
+			State:       player,
+		}
+		if entry := sourceTable.FindPlayerEntry(player.PlayerID); entry != nil {
+			carriedEntry := *entry
+			joinPlayer.Entry = &carriedEntry
+		}
+		if err := target.PlayerReserve(joinPlayer); err != nil {
+			if len(playerIDs) > 0 {
+				_ = target.PlayersLeave(playerIDs)
+			}
+			return err
+		}
+		playerIDs = append(playerIDs, player.PlayerID)
+	}
+
+	if err := source.PlayersLeave(playerIDs); err != nil {
+		_ = target.PlayersLeave(playerIDs)
+		return err
+	}
+
+	targetTableID := target.GetTable().ID
+	for _, player := range players {
+		if err := target.PlayerJoin(player.PlayerID); err != nil {
+			return err
+		}
+
+		c.mu.Lock()
+		if playerIdx := c.state.FindPlayerIdx(player.PlayerID); playerIdx != pwbtable.UnsetValue {
+			c.state.Players[playerIdx].TableID = targetTableID
+			c.state.Players[playerIdx].Chips = player.Bankroll
+		}
+		c.mu.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package competition
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+)
+
+func TestCalcTableMoves_Balanced(t *testing.T) {
+	moves := CalcTableMoves([]TablePlayerCount{
+		{TableID: "A", PlayerCount: 6},
+		{TableID: "B", PlayerCount: 5},
+		{TableID: "C", PlayerCount: 6},
+	}, 6)
+	assert.Empty(t, moves)
+}
+
+func TestCalcTableMoves_KeepWithinOneSeat(t *testing.T) {
+	moves := CalcTableMoves([]TablePlayerCount{
+		{TableID: "A", PlayerCount: 6},
+		{TableID: "B", PlayerCount: 3},
+		{TableID: "C", PlayerCount: 6},
+	}, 6)
+	assert.Equal(t, []TableMove{
+		{FromTableID: "A", ToTableID: "B", PlayerCount: 1},
+		{FromTableID: "C", ToTableID: "B", PlayerCount: 1},
+	}, moves)
+}
+
+func TestCalcTableMoves_BreakShortestTable(t *testing.T) {
+	moves := CalcTableMoves([]TablePlayerCount{
+		{TableID: "A", PlayerCount: 4},
+		{TableID: "B", PlayerCount: 2},
+		{TableID: "C", PlayerCount: 5},
+	}, 6)
+
+	// 11 players fit in 2 tables, B is broken
+	assert.Equal(t, []TableMove{
+		{FromTableID: "B", ToTableID: "C", PlayerCount: 1},
+		{FromTableID: "B", ToTableID: "A", PlayerCount: 1},
+	}, moves)
+}
+
+func TestCalcTableMoves_FinalTable(t *testing.T) {
+	moves := CalcTableMoves([]TablePlayerCount{
+		{TableID: "A", PlayerCount: 1},
+		{TableID: "B", PlayerCount: 3},
+	}, 6)
+	assert.Equal(t, []TableMove{
+		{FromTableID: "A", ToTableID: "B", PlayerCount: 1},
+	}, moves)
+}
+
+func TestSelectMovingPlayers(t *testing.T) {
+	table := &pwbtable.Table{
+		Meta: pwbtable.TableMeta{TableMaxSeatCount: 6},
+		State: &pwbtable.TableState{
+			CurrentBBSeat: 4,
+			PlayerStates: []*pwbtable.TablePlayerState{
+				{PlayerID: "Fred", Seat: 0, Bankroll: 1000},
+				{PlayerID: "Jeffrey", Seat: 2, Bankroll: 1000},
+				{PlayerID: "Chuck", Seat: 4, Bankroll: 1000},
+				{PlayerID: "Loz", Seat: 5, Bankroll: 0},
+			},
+		},
+	}
+
+	// Loz has no chips, Fred is the next big blind
+	players := SelectMovingPlayers(table, 2)
+	if assert.Len(t, players, 2) {
+		assert.Equal(t, "Fred", players[0].PlayerID)
+		assert.Equal(t, "Jeffrey", players[1].PlayerID)
+	}
+}
+
+func newTestTableEngine(t *testing.T, manager pwbtable.Manager, c *competition, maxSeatCount int, playerIDs ...string) pwbtable.TableEngine {
+	meta := c.setting.TableMeta
+	meta.CompetitionID = c.GetState().ID
+	meta.Mode = pwbtable.CompetitionMode_MTT
+	meta.TableMaxSeatCount = maxSeatCount
+
+	joinPlayers := make([]pwbtable.JoinPlayer, 0, len(playerIDs))
+	for _, playerID := range playerIDs {
+		joinPlayers = append(joinPlayers, pwbtable.JoinPlayer{PlayerID: playerID, RedeemChips: 1000, Seat: pwbtable.UnsetValue})
+	}
+
+	table, err := manager.CreateTable(nil, nil, pwbtable.TableSetting{TableID: fmt.Sprintf("table-%d", maxSeatCount), Meta: meta, JoinPlayers: joinPlayers})
+	assert.Nil(t, err, "create table failed")
+
+	tableEngine, err := manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+	return tableEngine
+}
+
+func TestMovePlayers_TargetFull(t *testing.T) {
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	cc, err := NewCompetition(manager, nil, nil, newTestCompetitionSetting())
+	assert.Nil(t, err, "create competition failed")
+	c := cc.(*competition)
+
+	source := newTestTableEngine(t, manager, c, 6, "Fred", "Jeffrey", "Chuck")
+	target := newTestTableEngine(t, manager, c, 2, "Lottie")
+
+	// only one seat is left at target
+	players := source.GetTable().State.PlayerStates[:2]
+	assert.Equal(t, pwbtable.ErrTableNoEmptySeats, c.movePlayers(source, target, players))
+
+	// nobody is moved
+	assert.Len(t, source.GetTable().State.PlayerStates, 3)
+	assert.Len(t, target.GetTable().State.PlayerStates, 1)
+	assert.Equal(t, "Lottie", target.GetTable().State.PlayerStates[0].PlayerID)
+}
+
+func TestMovePlayers_KeepPlayerState(t *testing.T) {
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	setting := newTestCompetitionSetting()
+	setting.TableMeta.TimeBank = pwbtable.TableTimeBankSetting{InitialSeconds: 30}
+	cc, err := NewCompetition(manager, nil, nil, setting)
+	assert.Nil(t, err, "create competition failed")
+	c := cc.(*competition)
+
+	source := newTestTableEngine(t, manager, c, 6, "Fred", "Jeffrey")
+	target := newTestTableEngine(t, manager, c, 2, "Lottie")
+
+	// Fred has used time bank and bought chips at source
+	sourceTable := source.GetTable()
+	fred := sourceTable.State.PlayerStates[sourceTable.FindPlayerIdx("Fred")]
+	fred.TimeBank = 7
+	sourceTable.State.PlayerEntries = append(sourceTable.State.PlayerEntries, &pwbtable.TablePlayerEntry{PlayerID: "Fred", ReBuyCount: 2, AddOnCount: 1})
+	assert.Nil(t, source.PlayerAutoMode("Fred", true))
+
+	assert.Nil(t, c.movePlayers(source, target, []*pwbtable.TablePlayerState{fred}))
+
+	targetTable := target.GetTable()
+	playerIdx := targetTable.FindPlayerIdx("Fred")
+	if !assert.NotEqual(t, pwbtable.UnsetValue, playerIdx, "Fred is not moved") {
+		return
+	}
+	assert.Equal(t, pwbtable.UnsetValue, source.GetTable().FindPlayerIdx("Fred"))
+
+	playerState := targetTable.State.PlayerStates[playerIdx]
+	assert.Equal(t, int64(1000), playerState.Bankroll)
+	assert.Equal(t, 7, playerState.TimeBank)
+	assert.True(t, playerState.IsAutoMode)
+
+	entry := targetTable.FindPlayerEntry("Fred")
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, 2, entry.ReBuyCount)
+		assert.Equal(t, 1, entry.AddOnCount)
+	}
+
+	// Jeffrey is not moved and keeps the default time bank
+	assert.Equal(t, 30, sourceTable.State.PlayerStates[sourceTable.FindPlayerIdx("Jeffrey")].TimeBank)
+}
@@ -0,0 +1,283 @@
+package competition
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+)
+
+var (
+	ErrCompetitionInvalidSetting          = errors.New("competition: invalid setting")
+	ErrCompetitionInvalidStatus           = errors.New("competition: invalid status")
+	ErrCompetitionPlayerAlreadyRegistered = errors.New("competition: player already registered")
+	ErrCompetitionPlayerNotFound          = errors.New("competition: player not found")
+	ErrCompetitionNoEmptySeats            = errors.New("competition: no empty seats available")
+	ErrCompetitionNotEnoughPlayers        = errors.New("competition: not enough players")
+)
+
+type CompetitionStatus string
+
+const (
+	CompetitionStatus_Registering CompetitionStatus = "registering"
+	CompetitionStatus_Playing     CompetitionStatus = "playing"
+	CompetitionStatus_Ended       CompetitionStatus = "ended"
+	CompetitionStatus_Closed      CompetitionStatus = "closed"
+)
+
+type Competition interface {
+	GetState() *CompetitionState
+	Register(playerID string) error
+	Unregister(playerID string) error
+	Start() error
+	Close() error
+}
+
+type CompetitionSetting struct {
+	CompetitionID  string                 `json:"competition_id"`
+	TableMeta      pwbtable.TableMeta     `json:"table_meta"` // template of every table, CompetitionID and Mode are overwritten
+	StartingChips  int64                  `json:"starting_chips"`
+	MinPlayerCount int                    `json:"min_player_count"`
+	MaxPlayerCount int                    `json:"max_player_count"` // 0 means unlimited
+	Payout         pwbtable.PayoutSetting `json:"payout"`           // entrant, rebuy and add-on counts and chip unit are decided by competition
+}
+
+type CompetitionState struct {
+	ID        string               `json:"id"`
+	Mode      string               `json:"mode"`
+	Status    CompetitionStatus    `json:"status"`
+	Players   []*CompetitionPlayer `json:"players"`
+	TableIDs  []string             `json:"table_ids"`
+	PrizePool int64                `json:"prize_pool"`
+	StartAt   int64                `json:"start_at"`
+	EndAt     int64                `json:"end_at"`
+}
+
+type CompetitionPlayer struct {
+	PlayerID     string `json:"player_id"`
+	TableID      string `json:"table_id"`
+	Chips        int64  `json:"chips"`
+	IsEliminated bool   `json:"is_eliminated"`
+	Place        int    `json:"place"` // finishing place, 0 until the player is finished
+	Prize        int64  `json:"prize"`
+	EliminatedAt int64  `json:"eliminated_at"`
+	ReBuyCount   int    `json:"rebuy_count"`
+	AddOnCount   int    `json:"add_on_count"`
+	ReEntryCount int    `json:"re_entry_count"`
+}
+
+type competition struct {
+	mu        sync.Mutex
+	balanceMu sync.Mutex
+	manager   pwbtable.Manager
+	options   *CompetitionOptions
+	callbacks *CompetitionCallbacks
+	setting   CompetitionSetting
+	state     *CompetitionState
+}
+
+func NewCompetition(manager pwbtable.Manager, options *CompetitionOptions, callbacks *CompetitionCallbacks, setting CompetitionSetting) (Competition, error) {
+	if setting.StartingChips <= 0 || setting.TableMeta.TableMaxSeatCount < 2 || setting.TableMeta.TableMinPlayerCount < 2 {
+		return nil, ErrCompetitionInvalidSetting
+	}
+
+	if setting.MaxPlayerCount > 0 && setting.MaxPlayerCount < setting.MinPlayerCount {
+		return nil, ErrCompetitionInvalidSetting
+	}
+
+	if err := setting.TableMeta.BlindStructure.Validate(); err != nil {
+		return nil, err
+	}
+
+	if setting.Payout.Structure.Type != "" {
+		if err := setting.Payout.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if setting.CompetitionID == "" {
+		setting.CompetitionID = uuid.New().String()
+	}
+
+	if options == nil {
+		options = NewCompetitionOptions()
+	}
+
+	if callbacks == nil {
+		callbacks = NewCompetitionCallbacks()
+	}
+
+	c := &competition{
+		manager:   manager,
+		options:   options,
+		callbacks: callbacks,
+		setting:   setting,
+		state: &CompetitionState{
+			ID:       setting.CompetitionID,
+			Mode:     pwbtable.CompetitionMode_MTT,
+			Status:   CompetitionStatus_Registering,
+			Players:  make([]*CompetitionPlayer, 0),
+			TableIDs: make([]string, 0),
+			StartAt:  pwbtable.UnsetValue,
+			EndAt:    pwbtable.UnsetValue,
+		},
+	}
+
+	return c, nil
+}
+
+func (cs CompetitionState) Clone() (*CompetitionState, error) {
+	encoded, err := json.Marshal(cs)
+	if err != nil {
+		return nil, err
+	}
+
+	var cloneState CompetitionState
+	if err := json.Unmarshal(encoded, &cloneState); err != nil {
+		return nil, err
+	}
+
+	return &cloneState, nil
+}
+
+func (cs CompetitionState) FindPlayerIdx(playerID string) int {
+	for idx, player := range cs.Players {
+		if player.PlayerID == playerID {
+			return idx
+		}
+	}
+	return pwbtable.UnsetValue
+}
+
+func (cs CompetitionState) AlivePlayers() []*CompetitionPlayer {
+	players := make([]*CompetitionPlayer, 0)
+	for _, player := range cs.Players {
+		if !player.IsEliminated {
+			players = append(players, player)
+		}
+	}
+	return players
+}
+
+func (c *competition) GetState() *CompetitionState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state, _ := c.state.Clone()
+	return state
+}
+
+func (c *competition) Register(playerID string) error {
+	c.mu.Lock()
+
+	if c.state.Status != CompetitionStatus_Registering {
+		c.mu.Unlock()
+		return ErrCompetitionInvalidStatus
+	}
+
+	if c.state.FindPlayerIdx(playerID) != pwbtable.UnsetValue {
+		c.mu.Unlock()
+		return ErrCompetitionPlayerAlreadyRegistered
+	}
+
+	if c.setting.MaxPlayerCount > 0 && len(c.state.Players) >= c.setting.MaxPlayerCount {
+		c.mu.Unlock()
+		return ErrCompetitionNoEmptySeats
+	}
+
+	c.state.Players = append(c.state.Players, &CompetitionPlayer{
+		PlayerID:     playerID,
+		Chips:        c.setting.StartingChips,
+		EliminatedAt: pwbtable.UnsetValue,
+	})
+	c.mu.Unlock()
+
+	c.emitCompetitionUpdated()
+	return nil
+}
+
+func (c *competition) Unregister(playerID string) error {
+	c.mu.Lock()
+
+	if c.state.Status != CompetitionStatus_Registering {
+		c.mu.Unlock()
+		return ErrCompetitionInvalidStatus
+	}
+
+	playerIdx := c.state.FindPlayerIdx(playerID)
+	if playerIdx == pwbtable.UnsetValue {
+		c.mu.Unlock()
+		return ErrCompetitionPlayerNotFound
+	}
+
+	c.state.Players = append(c.state.Players[:playerIdx], c.state.Players[playerIdx+1:]...)
+	c.mu.Unlock()
+
+	c.emitCompetitionUpdated()
+	return nil
+}
+
+func (c *competition) Start() error {
+	c.mu.Lock()
+
+	if c.state.Status != CompetitionStatus_Registering {
+		c.mu.Unlock()
+		return ErrCompetitionInvalidStatus
+	}
+
+	minPlayerCount := c.setting.MinPlayerCount
+	if minPlayerCount < c.setting.TableMeta.TableMinPlayerCount {
+		minPlayerCount = c.setting.TableMeta.TableMinPlayerCount
+	}
+	if len(c.state.Players) < minPlayerCount {
+		c.mu.Unlock()
+		return ErrCompetitionNotEnoughPlayers
+	}
+
+	c.state.Status = CompetitionStatus_Playing
+	c.state.StartAt = time.Now().Unix()
+	tableSettings := c.newTableSettings()
+	c.mu.Unlock()
+
+	// tables are created before any game is started so that players can be moved between them
+	for _, tableSetting := range tableSettings {
+		if err := c.createTable(tableSetting); err != nil {
+			c.cancelStart()
+			return err
+		}
+	}
+
+	c.emitCompetitionUpdated()
+
+	// every table keeps playing hands on its own
+	for _, tableSetting := range tableSettings {
+		go func(tableID string) {
+			if err := c.manager.StartTableGame(tableID); err != nil {
+				c.emitCompetitionErrorUpdated(err)
+			}
+		}(tableSetting.TableID)
+	}
+
+	return nil
+}
+
+func (c *competition) Close() error {
+	c.mu.Lock()
+	c.state.Status = CompetitionStatus_Closed
+	c.state.EndAt = time.Now().Unix()
+	tableIDs := c.state.TableIDs
+	c.state.TableIDs = make([]string, 0)
+	c.mu.Unlock()
+
+	for _, tableID := range tableIDs {
+		if err := c.manager.CloseTable(tableID); err != nil && err != pwbtable.ErrManagerTableNotFound {
+			return err
+		}
+	}
+
+	c.emitCompetitionUpdated()
+	return nil
+}
@@ -0,0 +1,279 @@
+package competition
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+	"github.com/weedbox/PokerWeedBox/pwbtable/actor"
+)
+
+func newTestCompetitionSetting() CompetitionSetting {
+	return CompetitionSetting{
+		TableMeta: pwbtable.TableMeta{
+			Rule:                pwbtable.CompetitionRule_Default,
+			MaxDuration:         60,
+			TableMaxSeatCount:   6,
+			TableMinPlayerCount: 2,
+			MinChipUnit:         10,
+			ActionTime:          10,
+			BlindStructure: pwbtable.TableBlindStructure{
+				Levels: []pwbtable.TableBlindLevel{
+					{Level: 1, SB: 10, BB: 20, Hands: 3},
+					{Level: 2, SB: 25, BB: 50, Hands: 3},
+					{Level: 3, SB: 50, BB: 100, Hands: 3},
+					{Level: 4, SB: 100, BB: 200, Hands: 3},
+					{Level: 5, SB: 250, BB: 500, Hands: 3},
+					{Level: 6, SB: 500, BB: 1000},
+				},
+			},
+		},
+		StartingChips:  1000,
+		MinPlayerCount: 2,
+	}
+}
+
+type testBots struct {
+	mu       sync.Mutex
+	manager  pwbtable.Manager
+	actors   map[string]actor.Actor
+	tableIDs map[string]string
+}
+
+func newTestBots(manager pwbtable.Manager) *testBots {
+	return &testBots{
+		manager:  manager,
+		actors:   make(map[string]actor.Actor),
+		tableIDs: make(map[string]string),
+	}
+}
+
+func (tb *testBots) add(t *testing.T, playerID string) {
+	a := actor.NewActor()
+	bot := actor.NewBotRunner(playerID)
+	bot.OnTableAutoJoinActionRequested(func(competitionID, tableID, playerID string) {
+		assert.Nil(t, tb.manager.PlayerJoin(tableID, playerID), fmt.Sprintf("%s join error", playerID))
+	})
+	a.SetRunner(bot)
+
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.actors[playerID] = a
+}
+
+// updateTableState binds actors to the table they are seated at, players are moved between tables.
+func (tb *testBots) updateTableState(table *pwbtable.Table) {
+	actors := make([]actor.Actor, 0)
+
+	tb.mu.Lock()
+	for _, player := range table.State.PlayerStates {
+		a, exist := tb.actors[player.PlayerID]
+		if !exist {
+			continue
+		}
+
+		if tb.tableIDs[player.PlayerID] != table.ID {
+			tableEngine, err := tb.manager.GetTableEngine(table.ID)
+			if err != nil {
+				continue
+			}
+			a.SetAdapter(actor.NewTableEngineAdapter(tableEngine, table))
+			tb.tableIDs[player.PlayerID] = table.ID
+		}
+		actors = append(actors, a)
+	}
+	tb.mu.Unlock()
+
+	for _, a := range actors {
+		a.GetTable().UpdateTableState(table)
+	}
+}
+
+func TestCompetition_MTT(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	playerCount := 15
+	manager := pwbtable.NewManager()
+	bots := newTestBots(manager)
+
+	options := NewCompetitionOptions()
+	options.TableEngineOptions.Interval = 0
+	options.TableEngineCallbacks.OnTableUpdated = bots.updateTableState
+	options.TableEngineCallbacks.OnTableErrorUpdated = func(table *pwbtable.Table, err error) {
+		t.Log("[Table] Error:", err)
+	}
+
+	var eliminatedLock sync.Mutex
+	eliminatedPlayerIDs := make([]string, 0)
+	callbacks := NewCompetitionCallbacks()
+	callbacks.OnCompetitionPlayerEliminated = func(c *CompetitionState, player *CompetitionPlayer) {
+		eliminatedLock.Lock()
+		defer eliminatedLock.Unlock()
+		eliminatedPlayerIDs = append(eliminatedPlayerIDs, player.PlayerID)
+		t.Logf("%s is eliminated at place %d", player.PlayerID, player.Place)
+	}
+	callbacks.OnCompetitionErrorUpdated = func(c *CompetitionState, err error) {
+		t.Log("[Competition] Error:", err)
+	}
+	callbacks.OnCompetitionEnded = func(c *CompetitionState) {
+		wg.Done()
+	}
+
+	c, err := NewCompetition(manager, options, callbacks, newTestCompetitionSetting())
+	assert.Nil(t, err, "create competition failed")
+
+	for i := 0; i < playerCount; i++ {
+		playerID := fmt.Sprintf("player-%d", i+1)
+		bots.add(t, playerID)
+		assert.Nil(t, c.Register(playerID), fmt.Sprintf("%s register error", playerID))
+	}
+	assert.Equal(t, ErrCompetitionPlayerAlreadyRegistered, c.Register("player-1"))
+
+	assert.Nil(t, c.Start(), "start competition failed")
+	assert.Len(t, c.GetState().TableIDs, 3)
+	assert.Equal(t, ErrCompetitionInvalidStatus, c.Register("late-player"))
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(3 * time.Minute):
+		assert.Fail(t, "competition is not ended in time")
+		assert.Nil(t, c.Close())
+		return
+	}
+
+	// check results
+	state := c.GetState()
+	assert.Equal(t, CompetitionStatus_Ended, state.Status)
+	assert.Empty(t, state.TableIDs)
+	assert.Len(t, eliminatedPlayerIDs, playerCount-1)
+
+	places := make(map[int]string)
+	totalChips := int64(0)
+	for _, player := range state.Players {
+		places[player.Place] = player.PlayerID
+		totalChips += player.Chips
+
+		if player.Place == 1 {
+			assert.False(t, player.IsEliminated)
+			assert.Equal(t, int64(playerCount)*1000, player.Chips)
+		} else {
+			assert.True(t, player.IsEliminated)
+			assert.Equal(t, int64(0), player.Chips)
+		}
+	}
+	assert.Equal(t, int64(playerCount)*1000, totalChips)
+	for place := 1; place <= playerCount; place++ {
+		assert.NotEmpty(t, places[place], fmt.Sprintf("place %d is missing", place))
+	}
+}
+
+func TestCompetition_InvalidSetting(t *testing.T) {
+	manager := pwbtable.NewManager()
+
+	setting := newTestCompetitionSetting()
+	setting.StartingChips = 0
+	_, err := NewCompetition(manager, nil, nil, setting)
+	assert.Equal(t, ErrCompetitionInvalidSetting, err)
+
+	c, err := NewCompetition(manager, nil, nil, newTestCompetitionSetting())
+	assert.Nil(t, err, "create competition failed")
+	assert.Nil(t, c.Register("Fred"))
+	assert.Equal(t, ErrCompetitionNotEnoughPlayers, c.Start())
+	assert.Nil(t, c.Unregister("Fred"))
+	assert.Equal(t, ErrCompetitionPlayerNotFound, c.Unregister("Fred"))
+}
+
+type failingManager struct {
+	pwbtable.Manager
+	tableIDs []string
+}
+
+var errTableUnavailable = errors.New("table unavailable")
+
+// CreateTable fails from the second table on
+func (m *failingManager) CreateTable(options *pwbtable.TableEngineOptions, callbacks *pwbtable.TableEngineCallbacks, setting pwbtable.TableSetting) (*pwbtable.Table, error) {
+	if len(m.tableIDs) > 0 {
+		return nil, errTableUnavailable
+	}
+
+	m.tableIDs = append(m.tableIDs, setting.TableID)
+	return m.Manager.CreateTable(options, callbacks, setting)
+}
+
+func TestCompetition_StartFailed(t *testing.T) {
+	manager := &failingManager{Manager: pwbtable.NewManager()}
+	defer manager.Reset()
+
+	c, err := NewCompetition(manager, nil, nil, newTestCompetitionSetting())
+	assert.Nil(t, err, "create competition failed")
+
+	// two tables are needed
+	for i := 0; i < 8; i++ {
+		assert.Nil(t, c.Register(fmt.Sprintf("player-%d", i)))
+	}
+	assert.Equal(t, errTableUnavailable, c.Start())
+
+	// created table is closed and competition is able to register again
+	assert.Len(t, manager.tableIDs, 1)
+	_, err = manager.GetTableEngine(manager.tableIDs[0])
+	assert.Equal(t, pwbtable.ErrManagerTableNotFound, err)
+
+	state := c.GetState()
+	assert.Equal(t, CompetitionStatus_Registering, state.Status)
+	assert.Equal(t, int64(pwbtable.UnsetValue), state.StartAt)
+	assert.Empty(t, state.TableIDs)
+	for _, player := range state.Players {
+		assert.Empty(t, player.TableID)
+	}
+	assert.Nil(t, c.Register("player-8"))
+}
+
+func TestCompetition_PrizePoolWithReBuys(t *testing.T) {
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	setting := newTestCompetitionSetting()
+	setting.Payout = pwbtable.PayoutSetting{
+		BuyIn:      100,
+		RebuyPrice: 100,
+		AddOnPrice: 50,
+		Structure: pwbtable.PayoutStructure{
+			Type:        pwbtable.PayoutStructureType_Ladder,
+			Percentages: []float64{70, 30},
+		},
+	}
+	cc, err := NewCompetition(manager, nil, nil, setting)
+	assert.Nil(t, err, "create competition failed")
+	c := cc.(*competition)
+
+	for _, playerID := range []string{"Fred", "Jeffrey", "Chuck"} {
+		assert.Nil(t, c.Register(playerID))
+	}
+
+	// two rebuys and an add-on are taken
+	for place, player := range c.state.Players {
+		player.Place = place + 1
+	}
+	c.state.Players[1].ReBuyCount = 2
+	c.state.Players[2].AddOnCount = 1
+
+	assert.Nil(t, c.payPlayers())
+	assert.Equal(t, int64(550), c.state.PrizePool)
+
+	total := int64(0)
+	for _, player := range c.state.Players {
+		total += player.Prize
+	}
+	assert.Equal(t, c.state.PrizePool, total)
+}
@@ -0,0 +1,307 @@
+package competition
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+)
+
+func (c *competition) newTableSettings() []pwbtable.TableSetting {
+	playerIDs := make([]string, 0, len(c.state.Players))
+	for _, player := range c.state.Players {
+		playerIDs = append(playerIDs, player.PlayerID)
+	}
+
+	c.options.RandSource.Shuffle(len(playerIDs), func(i, j int) {
+		playerIDs[i], playerIDs[j] = playerIDs[j], playerIDs[i]
+	})
+
+	maxSeatCount := c.setting.TableMeta.TableMaxSeatCount
+	tableCount := (len(playerIDs) + maxSeatCount - 1) / maxSeatCount
+
+	tableSettings := make([]pwbtable.TableSetting, 0, tableCount)
+	for i := 0; i < tableCount; i++ {
+		meta := c.setting.TableMeta
+		meta.CompetitionID = c.state.ID
+		meta.Mode = c.state.Mode
+		tableSettings = append(tableSettings, pwbtable.TableSetting{
+			TableID:     uuid.New().String(),
+			Meta:        meta,
+			JoinPlayers: make([]pwbtable.JoinPlayer, 0),
+		})
+	}
+
+	// deal players to tables one by one so that table sizes differ by one at most
+	for idx, playerID := range playerIDs {
+		tableSetting := &tableSettings[idx%tableCount]
+		tableSetting.JoinPlayers = append(tableSetting.JoinPlayers, pwbtable.JoinPlayer{
+			PlayerID:    playerID,
+			RedeemChips: c.setting.StartingChips,
+			Seat:        pwbtable.UnsetValue,
+		})
+	}
+
+	return tableSettings
+}
+
+func (c *competition) createTable(tableSetting pwbtable.TableSetting) error {
+	if _, err := c.manager.CreateTable(c.options.TableEngineOptions, c.newTableEngineCallbacks(), tableSetting); err != nil {
+		return err
+	}
+
+	// table is closed by cancelStart even if players fail to join
+	c.mu.Lock()
+	c.state.TableIDs = append(c.state.TableIDs, tableSetting.TableID)
+	c.mu.Unlock()
+
+	for _, joinPlayer := range tableSetting.JoinPlayers {
+		if err := c.manager.PlayerJoin(tableSetting.TableID, joinPlayer.PlayerID); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	for _, joinPlayer := range tableSetting.JoinPlayers {
+		c.state.Players[c.state.FindPlayerIdx(joinPlayer.PlayerID)].TableID = tableSetting.TableID
+	}
+	c.mu.Unlock()
+
+	return nil
+}
+
+// cancelStart closes tables created by Start and lets players register again.
+func (c *competition) cancelStart() {
+	c.mu.Lock()
+	c.state.Status = CompetitionStatus_Registering
+	c.state.StartAt = pwbtable.UnsetValue
+	tableIDs := c.state.TableIDs
+	c.state.TableIDs = make([]string, 0)
+	for _, player := range c.state.Players {
+		player.TableID = ""
+	}
+	c.mu.Unlock()
+
+	for _, tableID := range tableIDs {
+		if err := c.manager.CloseTable(tableID); err != nil && err != pwbtable.ErrManagerTableNotFound {
+			c.emitCompetitionErrorUpdated(err)
+		}
+	}
+}
+
+func (c *competition) newTableEngineCallbacks() *pwbtable.TableEngineCallbacks {
+	callbacks := *c.options.TableEngineCallbacks
+
+	onTableUpdated := callbacks.OnTableUpdated
+	callbacks.OnTableUpdated = func(table *pwbtable.Table) {
+		c.onTableUpdated(table)
+		onTableUpdated(table)
+	}
+
+	onTableStateUpdated := callbacks.OnTableStateUpdated
+	callbacks.OnTableStateUpdated = func(event string, table *pwbtable.Table) {
+		// moves players before next hand is opened
+		if table.State.Status == pwbtable.TableStateStatus_TableGameStandby {
+			c.balanceTables(table.ID)
+		}
+		onTableStateUpdated(event, table)
+	}
+
+	onTablePlayersEliminated := callbacks.OnTablePlayersEliminated
+	callbacks.OnTablePlayersEliminated = func(table *pwbtable.Table, players []*pwbtable.TablePlayerState) {
+		c.onTablePlayersEliminated(table, players)
+		onTablePlayersEliminated(table, players)
+	}
+
+	return &callbacks
+}
+
+func (c *competition) onTableUpdated(table *pwbtable.Table) {
+	c.updatePlayerEntries(table)
+
+	switch table.State.Status {
+	case pwbtable.TableStateStatus_TableGameSettled:
+		c.mu.Lock()
+		for _, playerState := range table.State.PlayerStates {
+			if playerIdx := c.state.FindPlayerIdx(playerState.PlayerID); playerIdx != pwbtable.UnsetValue {
+				c.state.Players[playerIdx].Chips = playerState.Bankroll
+			}
+		}
+		c.mu.Unlock()
+	case pwbtable.TableStateStatus_TablePausing:
+		// break of blind structure is resumed by table itself
+		if table.State.BlindState.IsBreaking() {
+			return
+		}
+
+		// table is waiting for players, handles it after game flow of the table is finished
+		go c.onTablePaused()
+	}
+}
+
+// updatePlayerEntries keeps counts of players seated at table, counts follow players who are moved to other tables.
+func (c *competition) updatePlayerEntries(table *pwbtable.Table) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, playerState := range table.State.PlayerStates {
+		entry := table.FindPlayerEntry(playerState.PlayerID)
+		playerIdx := c.state.FindPlayerIdx(playerState.PlayerID)
+		if entry == nil || playerIdx == pwbtable.UnsetValue {
+			continue
+		}
+
+		player := c.state.Players[playerIdx]
+		player.ReBuyCount = entry.ReBuyCount
+		player.AddOnCount = entry.AddOnCount
+		player.ReEntryCount = entry.ReEntryCount
+	}
+}
+
+func (c *competition) onTablePlayersEliminated(table *pwbtable.Table, players []*pwbtable.TablePlayerState) {
+	c.mu.Lock()
+
+	bustedPlayers := make([]pwbtable.PayoutBustedPlayer, 0, len(players))
+	for _, playerState := range players {
+		playerIdx := c.state.FindPlayerIdx(playerState.PlayerID)
+		if playerIdx == pwbtable.UnsetValue || c.state.Players[playerIdx].IsEliminated {
+			continue
+		}
+
+		bustedPlayers = append(bustedPlayers, pwbtable.PayoutBustedPlayer{
+			PlayerID:      playerState.PlayerID,
+			StartingStack: table.StartingStackSize(playerState.PlayerID),
+		})
+	}
+
+	now := time.Now().Unix()
+	remaining := len(c.state.AlivePlayers())
+	eliminatedPlayers := make([]*CompetitionPlayer, 0)
+	for _, place := range pwbtable.RankBustedPlayers(bustedPlayers, remaining) {
+		player := c.state.Players[c.state.FindPlayerIdx(place.PlayerID)]
+		player.Chips = 0
+		player.IsEliminated = true
+		player.Place = place.Place
+		player.EliminatedAt = now
+
+		eliminatedPlayer := *player
+		eliminatedPlayers = append(eliminatedPlayers, &eliminatedPlayer)
+	}
+	remaining -= len(eliminatedPlayers)
+
+	// the last one holds all chips, tables are closed once the final table is paused
+	var payErr error
+	if remaining == 1 && c.state.Status == CompetitionStatus_Playing {
+		c.state.AlivePlayers()[0].Place = 1
+		c.state.Status = CompetitionStatus_Ended
+		c.state.EndAt = now
+		payErr = c.payPlayers()
+	}
+
+	isPlaying := c.state.Status == CompetitionStatus_Playing
+	state, _ := c.state.Clone()
+	c.mu.Unlock()
+
+	if payErr != nil {
+		c.emitCompetitionErrorUpdated(payErr)
+	}
+
+	for _, player := range eliminatedPlayers {
+		c.callbacks.OnCompetitionPlayerEliminated(state, player)
+	}
+	c.callbacks.OnCompetitionUpdated(state)
+
+	// seats are released, paused tables may be able to be balanced now
+	if isPlaying {
+		go c.balanceTables("")
+	}
+}
+
+func (c *competition) onTablePaused() {
+	c.mu.Lock()
+	status := c.state.Status
+	c.mu.Unlock()
+
+	switch status {
+	case CompetitionStatus_Ended:
+		c.balanceMu.Lock()
+		defer c.balanceMu.Unlock()
+		c.finish()
+	case CompetitionStatus_Playing:
+		c.balanceTables("")
+	}
+}
+
+func (c *competition) finish() {
+	c.mu.Lock()
+	tableIDs := c.state.TableIDs
+	c.state.TableIDs = make([]string, 0)
+	c.mu.Unlock()
+
+	// finished already
+	if len(tableIDs) == 0 {
+		return
+	}
+
+	for _, tableID := range tableIDs {
+		if err := c.manager.CloseTable(tableID); err != nil && err != pwbtable.ErrManagerTableNotFound {
+			c.emitCompetitionErrorUpdated(err)
+		}
+	}
+
+	state := c.GetState()
+	c.callbacks.OnCompetitionUpdated(state)
+	c.callbacks.OnCompetitionEnded(state)
+}
+
+func (c *competition) payPlayers() error {
+	if c.setting.Payout.Structure.Type == "" {
+		return nil
+	}
+
+	// rebuys and add-ons which players actually took go to prize pool
+	payout := c.setting.Payout
+	payout.EntrantCount = len(c.state.Players)
+	payout.RebuyCount = 0
+	payout.AddOnCount = 0
+	for _, player := range c.state.Players {
+		payout.RebuyCount += player.ReBuyCount
+		payout.AddOnCount += player.AddOnCount
+	}
+	payout.MinChipUnit = c.setting.TableMeta.MinChipUnit
+	prizes, err := payout.Prizes()
+	if err != nil {
+		return err
+	}
+
+	places := make([]*pwbtable.PayoutPlace, 0, len(c.state.Players))
+	for _, player := range c.state.Players {
+		places = append(places, &pwbtable.PayoutPlace{PlayerID: player.PlayerID, Place: player.Place})
+	}
+
+	playerPrizes := pwbtable.SplitPrizes(prizes, places, payout.MinChipUnit)
+	for _, player := range c.state.Players {
+		player.Prize = playerPrizes[player.PlayerID]
+	}
+
+	c.state.PrizePool = payout.PrizePool()
+	return nil
+}
+
+func (c *competition) removeTableID(tableID string) {
+	tableIDs := make([]string, 0, len(c.state.TableIDs))
+	for _, id := range c.state.TableIDs {
+		if id != tableID {
+			tableIDs = append(tableIDs, id)
+		}
+	}
+	c.state.TableIDs = tableIDs
+}
+
+func (c *competition) emitCompetitionUpdated() {
+	c.callbacks.OnCompetitionUpdated(c.GetState())
+}
+
+func (c *competition) emitCompetitionErrorUpdated(err error) {
+	c.callbacks.OnCompetitionErrorUpdated(c.GetState(), err)
+}
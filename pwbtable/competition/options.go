@@ -0,0 +1,35 @@
+package competition
+
+import (
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+)
+
+type CompetitionCallbacks struct {
+	OnCompetitionUpdated          func(c *CompetitionState)
+	OnCompetitionErrorUpdated     func(c *CompetitionState, err error)
+	OnCompetitionPlayerEliminated func(c *CompetitionState, player *CompetitionPlayer)
+	OnCompetitionEnded            func(c *CompetitionState)
+}
+
+func NewCompetitionCallbacks() *CompetitionCallbacks {
+	return &CompetitionCallbacks{
+		OnCompetitionUpdated:          func(*CompetitionState) {},
+		OnCompetitionErrorUpdated:     func(*CompetitionState, error) {},
+		OnCompetitionPlayerEliminated: func(*CompetitionState, *CompetitionPlayer) {},
+		OnCompetitionEnded:            func(*CompetitionState) {},
+	}
+}
+
+type CompetitionOptions struct {
+	TableEngineOptions   *pwbtable.TableEngineOptions
+	TableEngineCallbacks *pwbtable.TableEngineCallbacks // OnTableUpdated and OnTablePlayersEliminated are still called after competition handles them
+	RandSource           pwbtable.RandSource            // decides which table players are seated at
+}
+
+func NewCompetitionOptions() *CompetitionOptions {
+	return &CompetitionOptions{
+		TableEngineOptions:   pwbtable.NewTableEngineOptions(),
+		TableEngineCallbacks: pwbtable.NewTableEngineCallbacks(),
+		RandSource:           pwbtable.NewCryptoRandSource(),
+	}
+}
@@ -5,12 +5,22 @@ const (
 	UnsetValue = -1
 
 	// CompetitionMode
-	CompetitionMode_Cash = "cash"
+	CompetitionMode_Cash     = "cash"
+	CompetitionMode_MTT      = "mtt"
+	CompetitionMode_SitAndGo = "sit_and_go"
 
 	// CompetitionRule
 	CompetitionRule_Default   = "default"
 	CompetitionRule_ShortDeck = "short_deck"
 	CompetitionRule_Omaha     = "omaha"
+	CompetitionRule_OmahaHiLo = "omaha_hi_lo"
+	CompetitionRule_Omaha5    = "omaha_5"
+	CompetitionRule_Omaha6    = "omaha_6"
+
+	// Betting Limit
+	BettingLimit_NoLimit    = "no_limit"
+	BettingLimit_PotLimit   = "pot_limit"
+	BettingLimit_FixedLimit = "fixed_limit"
 
 	// Position
 	Position_Unknown = "unknown"
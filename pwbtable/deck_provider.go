@@ -0,0 +1,89 @@
+package pwbtable
+
+import (
+	"sort"
+	"sync"
+)
+
+// DeckProvider provides the deck of every new game, the first card in deck is dealt first.
+type DeckProvider interface {
+	NewDeck(rule string) []string
+}
+
+type shuffledDeckProvider struct {
+	rs RandSource
+}
+
+func NewShuffledDeckProvider(rs RandSource) DeckProvider {
+	return &shuffledDeckProvider{
+		rs: rs,
+	}
+}
+
+func (p *shuffledDeckProvider) NewDeck(rule string) []string {
+	deck := NewDeckCards(rule)
+	p.rs.Shuffle(len(deck), func(i, j int) {
+		deck[i], deck[j] = deck[j], deck[i]
+	})
+	return deck
+}
+
+type fixedDeckProvider struct {
+	mu    sync.Mutex
+	decks [][]string
+	next  int
+}
+
+// NewFixedDeckProvider provides the given decks in order and keeps the last one afterwards.
+func NewFixedDeckProvider(decks ...[]string) DeckProvider {
+	return &fixedDeckProvider{
+		decks: decks,
+	}
+}
+
+func (p *fixedDeckProvider) NewDeck(rule string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.decks) == 0 {
+		return NewDeckCards(rule)
+	}
+
+	deck := p.decks[p.next]
+	if p.next < len(p.decks)-1 {
+		p.next++
+	}
+
+	return append(make([]string, 0, len(deck)), deck...)
+}
+
+const (
+	boardCardsCount = 5
+	burnCardsCount  = 3
+)
+
+// HoleCardsCount returns how many hole cards are dealt to every player of rule.
+func HoleCardsCount(rule string) int {
+	return ruleSetOf(rule).HoleCardsCount()
+}
+
+// validateDeckSize makes sure a full table is able to be dealt with burn cards and board.
+func validateDeckSize(rule string, maxSeatCount int) error {
+	rs, err := GetRuleSet(rule)
+	if err != nil {
+		return ErrTableInvalidCreateSetting
+	}
+
+	if maxSeatCount*rs.HoleCardsCount()+burnCardsCount+boardCardsCount > len(rs.NewDeck()) {
+		return ErrTableInvalidCreateSetting
+	}
+	return nil
+}
+
+// NewDeckCards returns all cards of rule in sorted order, so that a seeded shuffle always deals the same cards.
+func NewDeckCards(rule string) []string {
+	// deck of rule set may be shuffled already
+	deck := ruleSetOf(rule).NewDeck()
+	sort.Strings(deck)
+	return deck
+}
@@ -3,7 +3,6 @@ package pwbtable
 import (
 	"errors"
 	"fmt"
-	"strings"
 	"sync"
 	"time"
 
@@ -20,6 +19,22 @@ var (
 	ErrTablePlayerInvalidAction     = errors.New("table: player invalid action")
 	ErrTablePlayerSeatUnavailable   = errors.New("table: player seat unavailable")
 	ErrTableOpenGameFailed          = errors.New("table: failed to open game")
+	ErrTablePlayerNoTimeBank        = errors.New("table: player has no time bank left")
+	ErrTableInvalidRestoreState     = errors.New("table: invalid restore state")
+	ErrTableInvalidBlindStructure   = errors.New("table: invalid blind structure")
+	ErrTableReBuyNotAllowed         = errors.New("table: rebuy is not allowed")
+	ErrTableAddOnNotAllowed         = errors.New("table: add-on is not allowed")
+	ErrTableReEntryNotAllowed       = errors.New("table: re-entry is not allowed")
+	ErrTableBuyInLimitReached       = errors.New("table: player buy-in limit reached")
+	ErrTableBuyInPeriodEnded        = errors.New("table: buy-in period has ended")
+	ErrTablePlayerStackTooLarge     = errors.New("table: player stack is too large to rebuy")
+	ErrTableInvalidBuyIn            = errors.New("table: invalid buy-in chips")
+	ErrTableBuyInTooSmall           = errors.New("table: buy-in is less than minimum")
+	ErrTableBuyInTooLarge           = errors.New("table: buy-in exceeds maximum")
+	ErrTableWagerExceedsLimit       = errors.New("table: wager exceeds betting limit")
+	ErrTableInvalidWagerSize        = errors.New("table: wager size is not allowed by betting limit")
+	ErrTableRaiseCapReached         = errors.New("table: raise cap of the round is reached")
+	ErrTableHiLoPotMismatch         = errors.New("table: pots of game result do not match pots of game")
 )
 
 type TableEngineOpt func(*tableEngine)
@@ -31,10 +46,16 @@ type TableEngine interface {
 	OnTablePlayerStateUpdated(fn func(string, string, *TablePlayerState))
 	OnTablePlayerReserved(fn func(competitionID, tableID string, playerState *TablePlayerState))
 	OnGamePlayerActionUpdated(fn func(TablePlayerGameAction))
+	OnTableViewUpdated(fn func(playerID string, table *Table))
+	OnTablePlayersEliminated(fn func(table *Table, players []*TablePlayerState))
+	SubscribeTableEvents(options *TableEventSubscriptionOptions) TableEventSubscription
+	SubscribeTableEventsFunc(options *TableEventSubscriptionOptions, fn func(*TableEvent)) TableEventSubscription
 
 	GetTable() *Table
 	GetGame() Game
+	HandHistory(gameID string) (*HandHistory, error)
 	CreateTable(tableSetting TableSetting) (*Table, error)
+	RestoreTable(table *Table) error
 	PauseTable() error
 	CloseTable() error
 	StartTableGame() error
@@ -44,6 +65,10 @@ type TableEngine interface {
 	PlayerReserve(joinPlayer JoinPlayer) error
 	PlayerJoin(playerID string) error
 	PlayerRedeemChips(joinPlayer JoinPlayer) error
+	PlayerAddOn(joinPlayer JoinPlayer) error
+	PlayerSitOut(playerID string) error
+	PlayerSitIn(playerID string, isWaitingBB bool) error
+	PlayerAutoMode(playerID string, isOn bool) error
 	PlayersLeave(playerIDs []string) error
 
 	PlayerReady(playerID string) error
@@ -55,6 +80,7 @@ type TableEngine interface {
 	PlayerCheck(playerID string) error
 	PlayerFold(playerID string) error
 	PlayerPass(playerID string) error
+	PlayerExtendTime(playerID string, duration int) error
 }
 
 type tableEngine struct {
@@ -63,14 +89,25 @@ type tableEngine struct {
 	table                     *Table
 	game                      Game
 	gameBackend               GameBackend
+	rand                      RandSource
+	deckProvider              DeckProvider
 	rg                        *syncsaga.ReadyGroup
 	tb                        *timebank.TimeBank
+	actionTimerKey            string
+	lastStatus                TableStateStatus
+	events                    *tableEventHub
+	handHistoryRecorder       HandHistoryRecorder
+	tableStore                TableStore
+	restoredActionEndAt       int64
+	breakTimer                *time.Timer
 	onTableUpdated            func(*Table)
 	onTableErrorUpdated       func(*Table, error)
 	onTableStateUpdated       func(string, *Table)
 	onTablePlayerStateUpdated func(string, string, *TablePlayerState)
 	onTablePlayerReserved     func(competitionID, tableID string, playerState *TablePlayerState)
 	onGamePlayerActionUpdated func(TablePlayerGameAction)
+	onTableViewUpdated        func(playerID string, table *Table)
+	onTablePlayersEliminated  func(table *Table, players []*TablePlayerState)
 }
 
 func NewTableEngine(options *TableEngineOptions, opts ...TableEngineOpt) TableEngine {
@@ -79,18 +116,29 @@ func NewTableEngine(options *TableEngineOptions, opts ...TableEngineOpt) TableEn
 		options:                   options,
 		rg:                        syncsaga.NewReadyGroup(),
 		tb:                        timebank.NewTimeBank(),
+		events:                    newTableEventHub(),
 		onTableUpdated:            callbacks.OnTableUpdated,
 		onTableErrorUpdated:       callbacks.OnTableErrorUpdated,
 		onTableStateUpdated:       callbacks.OnTableStateUpdated,
 		onTablePlayerStateUpdated: callbacks.OnTablePlayerStateUpdated,
 		onTablePlayerReserved:     callbacks.OnTablePlayerReserved,
 		onGamePlayerActionUpdated: callbacks.OnGamePlayerActionUpdated,
+		onTableViewUpdated:        callbacks.OnTableViewUpdated,
+		onTablePlayersEliminated:  callbacks.OnTablePlayersEliminated,
 	}
 
 	for _, opt := range opts {
 		opt(te)
 	}
 
+	if te.rand == nil {
+		te.rand = NewCryptoRandSource()
+	}
+
+	if te.deckProvider == nil {
+		te.deckProvider = NewShuffledDeckProvider(te.rand)
+	}
+
 	return te
 }
 
@@ -100,6 +148,30 @@ func WithGameBackend(gb GameBackend) TableEngineOpt {
 	}
 }
 
+func WithRandSource(rs RandSource) TableEngineOpt {
+	return func(te *tableEngine) {
+		te.rand = rs
+	}
+}
+
+func WithDeckProvider(dp DeckProvider) TableEngineOpt {
+	return func(te *tableEngine) {
+		te.deckProvider = dp
+	}
+}
+
+func WithTableStore(store TableStore) TableEngineOpt {
+	return func(te *tableEngine) {
+		te.tableStore = store
+	}
+}
+
+func WithHandHistoryRecorder(r HandHistoryRecorder) TableEngineOpt {
+	return func(te *tableEngine) {
+		te.handHistoryRecorder = r
+	}
+}
+
 func (te *tableEngine) OnTableUpdated(fn func(*Table)) {
 	te.onTableUpdated = fn
 }
@@ -124,6 +196,30 @@ func (te *tableEngine) OnGamePlayerActionUpdated(fn func(TablePlayerGameAction))
 	te.onGamePlayerActionUpdated = fn
 }
 
+func (te *tableEngine) OnTableViewUpdated(fn func(string, *Table)) {
+	te.onTableViewUpdated = fn
+}
+
+func (te *tableEngine) OnTablePlayersEliminated(fn func(*Table, []*TablePlayerState)) {
+	te.onTablePlayersEliminated = fn
+}
+
+func (te *tableEngine) SubscribeTableEvents(options *TableEventSubscriptionOptions) TableEventSubscription {
+	return te.events.subscribe(options)
+}
+
+// SubscribeTableEventsFunc calls fn for every event on its own goroutine,
+// fn must not call back into the engine, events of player actions are published under the table lock and a blocking subscription waits for fn forever.
+func (te *tableEngine) SubscribeTableEventsFunc(options *TableEventSubscriptionOptions, fn func(*TableEvent)) TableEventSubscription {
+	s := te.events.subscribe(options)
+	go func() {
+		for event := range s.Events() {
+			fn(event)
+		}
+	}()
+	return s
+}
+
 func (te *tableEngine) GetTable() *Table {
 	return te.table
 }
@@ -132,12 +228,81 @@ func (te *tableEngine) GetGame() Game {
 	return te.game
 }
 
+func (te *tableEngine) HandHistory(gameID string) (*HandHistory, error) {
+	if te.handHistoryRecorder == nil {
+		return nil, ErrHandHistoryDisabled
+	}
+
+	return te.handHistoryRecorder.HandHistory(gameID)
+}
+
 func (te *tableEngine) CreateTable(tableSetting TableSetting) (*Table, error) {
 	// validate tableSetting
 	if len(tableSetting.JoinPlayers) > tableSetting.Meta.TableMaxSeatCount {
 		return nil, ErrTableInvalidCreateSetting
 	}
 
+	if err := validateDeckSize(tableSetting.Meta.Rule, tableSetting.Meta.TableMaxSeatCount); err != nil {
+		return nil, err
+	}
+
+	if err := validateBettingLimit(tableSetting.Meta.BettingLimit()); err != nil {
+		return nil, err
+	}
+
+	if err := tableSetting.Meta.FixedLimit.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := tableSetting.Meta.BlindStructure.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := tableSetting.Meta.ReBuy.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := tableSetting.Meta.AddOn.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := tableSetting.Meta.ReEntry.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := tableSetting.Meta.Cash.Validate(); err != nil {
+		return nil, err
+	}
+
+	// house rake is only taken in cash game
+	if err := tableSetting.Meta.Rake.Validate(); err != nil {
+		return nil, err
+	}
+
+	if tableSetting.Meta.Rake.IsEnabled() && tableSetting.Meta.Mode != CompetitionMode_Cash {
+		return nil, ErrTableInvalidCreateSetting
+	}
+
+	if err := tableSetting.Meta.SitOut.Validate(); err != nil {
+		return nil, err
+	}
+
+	// sit & go raises blinds by itself
+	if tableSetting.Meta.Mode == CompetitionMode_SitAndGo {
+		if !tableSetting.Meta.BlindStructure.IsEnabled() {
+			return nil, ErrTableInvalidCreateSetting
+		}
+
+		if err := tableSetting.Meta.SitAndGo.Validate(); err != nil {
+			return nil, err
+		}
+
+		// standings are decided by the fixed entrants
+		if tableSetting.Meta.ReBuy.MaxCount > 0 || tableSetting.Meta.ReEntry.MaxCount > 0 {
+			return nil, ErrTableInvalidCreateSetting
+		}
+	}
+
 	// create table instance
 	table := &Table{
 		ID: tableSetting.TableID,
@@ -151,11 +316,14 @@ func (te *tableEngine) CreateTable(tableSetting TableSetting) (*Table, error) {
 		GameCount: 0,
 		StartAt:   UnsetValue,
 		BlindState: &TableBlindState{
-			Level:  0,
-			Ante:   UnsetValue,
-			Dealer: UnsetValue,
-			SB:     UnsetValue,
-			BB:     UnsetValue,
+			Level:              0,
+			Ante:               UnsetValue,
+			Dealer:             UnsetValue,
+			SB:                 UnsetValue,
+			BB:                 UnsetValue,
+			LevelIndex:         UnsetValue,
+			NextLevelAt:        UnsetValue,
+			NextLevelGameCount: UnsetValue,
 		},
 		CurrentDealerSeat: UnsetValue,
 		CurrentBBSeat:     UnsetValue,
@@ -163,11 +331,18 @@ func (te *tableEngine) CreateTable(tableSetting TableSetting) (*Table, error) {
 		PlayerStates:      make([]*TablePlayerState, 0),
 		GamePlayerIndexes: make([]int, 0),
 		Status:            TableStateStatus_TableCreated,
+		ActionEndAt:       UnsetValue,
+		Standings:         make([]*TableStanding, 0),
+		PlayerEntries:     make([]*TablePlayerEntry, 0),
+		LeftPlayers:       make([]*TableLeftPlayer, 0),
+		Rake: TableRakeState{
+			PotRakes: make([]int64, 0),
+		},
 	}
 	table.State = &state
 	te.table = table
 
-	te.emitEvent("CreateTable", "")
+	te.emitEvent(TableEventKind_TableCreated, "", nil)
 
 	// handle auto join players
 	if len(tableSetting.JoinPlayers) > 0 {
@@ -175,27 +350,90 @@ func (te *tableEngine) CreateTable(tableSetting TableSetting) (*Table, error) {
 			return nil, err
 		}
 
-		te.emitEvent("CreateTable -> Auto Add Players", "")
+		te.emitEvent(TableEventKind_TablePlayersAutoAdded, "", tableSetting.JoinPlayers)
+		te.startSitAndGo()
 	}
 
 	return te.table, nil
 }
 
+// RestoreTable rebuilds table and game from snapshot and resumes play.
+func (te *tableEngine) RestoreTable(table *Table) error {
+	if table == nil || table.State == nil || table.State.Status == TableStateStatus_TableClosed {
+		return ErrTableInvalidRestoreState
+	}
+
+	cloneTable, err := table.Clone()
+	if err != nil {
+		return err
+	}
+
+	status := cloneTable.State.Status
+	te.table = cloneTable
+	te.table.State.Status = TableStateStatus_TableRestoring
+	te.restoredActionEndAt = te.table.State.ActionEndAt
+	te.table.State.ActionEndAt = UnsetValue
+	te.emitEvent(TableEventKind_TableRestored, "", status)
+
+	te.table.State.Status = status
+	te.scheduleBreakEnd()
+	switch status {
+	case TableStateStatus_TableCreated:
+		for _, player := range te.table.State.PlayerStates {
+			if !player.IsIn {
+				te.playersAutoIn()
+				break
+			}
+		}
+	case TableStateStatus_TableGameOpened:
+		return te.startGame()
+	case TableStateStatus_TableGamePlaying:
+		return te.resumeGame()
+	case TableStateStatus_TableGameSettled, TableStateStatus_TableGameStandby:
+		go func() {
+			if err := te.continueGame(); err != nil {
+				te.emitErrorEvent(TableEventKind_TableRestored, "", err)
+			}
+		}()
+	}
+
+	return nil
+}
+
 func (te *tableEngine) PauseTable() error {
 	te.table.State.Status = TableStateStatus_TablePausing
+
+	te.emitEvent(TableEventKind_TablePaused, "", nil)
 	return nil
 }
 
 func (te *tableEngine) CloseTable() error {
 	te.table.State.Status = TableStateStatus_TableClosed
+	te.stopActionTimer()
+	te.stopBreakTimer()
 
-	te.emitEvent("CloseTable", "")
+	te.emitEvent(TableEventKind_TableClosed, "", nil)
+	te.events.close()
+
+	// closed table is never restored
+	if te.tableStore != nil {
+		if err := te.tableStore.DeleteTable(te.table.ID); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 func (te *tableEngine) StartTableGame() error {
 	te.table.State.StartAt = time.Now().Unix()
-	te.emitEvent("StartTableGame", "")
+	te.emitEvent(TableEventKind_TableGameStarted, "", nil)
+
+	te.startBlindStructure()
+	if te.table.State.BlindState.IsBreaking() {
+		te.table.State.Status = TableStateStatus_TablePausing
+		te.emitEvent(TableEventKind_TablePaused, "", nil)
+		return nil
+	}
 
 	return te.TableGameOpen()
 }
@@ -232,7 +470,7 @@ func (te *tableEngine) TableGameOpen() error {
 		}
 	}
 	te.table = newTable
-	te.emitEvent("TableGameOpen", "")
+	te.emitEvent(TableEventKind_TableGameOpened, "", nil)
 
 	return te.startGame()
 }
@@ -243,6 +481,10 @@ func (te *tableEngine) UpdateBlind(level int, ante, dealer, sb, bb int64) {
 	te.table.State.BlindState.Dealer = dealer
 	te.table.State.BlindState.SB = sb
 	te.table.State.BlindState.BB = bb
+
+	// fixed limit bets follow big blind
+	te.table.State.BlindState.SmallBet = 0
+	te.table.State.BlindState.BigBet = 0
 }
 
 func (te *tableEngine) PlayerReserve(joinPlayer JoinPlayer) error {
@@ -257,19 +499,53 @@ func (te *tableEngine) PlayerReserve(joinPlayer JoinPlayer) error {
 			return ErrTableNoEmptySeats
 		}
 
+		if err := te.validateCashBuyIn(joinPlayer); err != nil {
+			return err
+		}
+
+		// ReEntry
+		entry := te.table.FindPlayerEntry(joinPlayer.PlayerID)
+		isReEntry := entry != nil && entry.IsEliminated
+		if isReEntry {
+			if err := te.validateReEntry(joinPlayer.PlayerID); err != nil {
+				return err
+			}
+		}
+
 		// BuyIn
 		if err := te.batchAddPlayers([]JoinPlayer{joinPlayer}); err != nil {
 			return err
 		}
+		targetPlayerIdx = te.table.FindPlayerIdx(joinPlayer.PlayerID)
+
+		if isReEntry {
+			entry.ReEntryCount++
+			entry.IsEliminated = false
+		}
 	} else {
 		// ReBuy
-		// 補碼要檢查玩家是否介於 Dealer-BB 之間
 		playerState := te.table.State.PlayerStates[targetPlayerIdx]
+		if err := te.validateReBuy(playerState); err != nil {
+			return err
+		}
+
+		if err := te.validateCashTopUp(playerState, joinPlayer.RedeemChips); err != nil {
+			return err
+		}
+
+		if te.table.IsEntryTracked() {
+			te.playerEntry(playerState.PlayerID).ReBuyCount++
+		}
+
+		// 補碼要檢查玩家是否介於 Dealer-BB 之間
 		playerState.IsBetweenDealerBB = IsBetweenDealerBB(playerState.Seat, te.table.State.CurrentDealerSeat, te.table.State.CurrentBBSeat, te.table.Meta.TableMaxSeatCount, te.table.Meta.Rule)
-		playerState.Bankroll += joinPlayer.RedeemChips
+		te.topUp(playerState, joinPlayer.RedeemChips)
+		te.emitTablePlayerStateEvent(playerState)
 	}
 
-	te.emitEvent("PlayerReserve", joinPlayer.PlayerID)
+	te.emitTablePlayerReservedEvent(te.table.State.PlayerStates[targetPlayerIdx])
+	te.emitEvent(TableEventKind_PlayerReserved, joinPlayer.PlayerID, joinPlayer)
+	te.startSitAndGo()
 
 	return nil
 }
@@ -289,12 +565,15 @@ func (te *tableEngine) PlayerJoin(playerID string) error {
 	}
 
 	te.table.State.PlayerStates[playerIdx].IsIn = true
+	te.emitTablePlayerStateEvent(te.table.State.PlayerStates[playerIdx])
 
-	if te.table.State.Status == TableStateStatus_TableBalancing {
+	// sit & go is waiting for all players in before starting
+	isSitAndGoWaiting := te.table.Meta.Mode == CompetitionMode_SitAndGo && te.table.State.StartAt == UnsetValue
+	if te.table.State.Status == TableStateStatus_TableBalancing || isSitAndGoWaiting {
 		te.rg.Ready(int64(playerIdx))
 	}
 
-	te.emitEvent("PlayerJoin", playerID)
+	te.emitEvent(TableEventKind_PlayerJoined, playerID, nil)
 	return nil
 }
 
@@ -306,12 +585,25 @@ func (te *tableEngine) PlayerRedeemChips(joinPlayer JoinPlayer) error {
 	}
 
 	playerState := te.table.State.PlayerStates[playerIdx]
+	if err := te.validateReBuy(playerState); err != nil {
+		return err
+	}
+
+	if err := te.validateCashTopUp(playerState, joinPlayer.RedeemChips); err != nil {
+		return err
+	}
+
+	if te.table.IsEntryTracked() {
+		te.playerEntry(playerState.PlayerID).ReBuyCount++
+	}
+
 	if playerState.Bankroll == 0 {
 		playerState.IsBetweenDealerBB = IsBetweenDealerBB(playerState.Seat, te.table.State.CurrentDealerSeat, te.table.State.CurrentBBSeat, te.table.Meta.TableMaxSeatCount, te.table.Meta.Rule)
 	}
-	playerState.Bankroll += joinPlayer.RedeemChips
+	te.topUp(playerState, joinPlayer.RedeemChips)
+	te.emitTablePlayerStateEvent(playerState)
 
-	te.emitEvent("PlayerRedeemChips", joinPlayer.PlayerID)
+	te.emitEvent(TableEventKind_PlayerChipsRedeemed, joinPlayer.PlayerID, joinPlayer)
 	return nil
 }
 
@@ -319,9 +611,7 @@ func (te *tableEngine) PlayersLeave(playerIDs []string) error {
 	te.lock.Lock()
 	defer te.lock.Unlock()
 
-	te.batchRemovePlayers(playerIDs)
-	te.emitEvent("PlayersLeave", strings.Join(playerIDs, ","))
-
+	te.leavePlayers(playerIDs)
 	return nil
 }
 
@@ -347,7 +637,10 @@ func (te *tableEngine) PlayerPay(playerID string, chips int64) error {
 		return err
 	}
 
-	_, err := te.game.Pay(gamePlayerIdx, chips)
+	gs, err := te.game.Pay(gamePlayerIdx, chips)
+	if err == nil {
+		te.emitGamePlayerActionEvent(te.newGamePlayerAction(gs, gamePlayerIdx, Action_Pay, chips))
+	}
 	return err
 }
 
@@ -360,15 +653,25 @@ func (te *tableEngine) PlayerBet(playerID string, chips int64) error {
 		return err
 	}
 
-	_, err := te.game.Bet(gamePlayerIdx, chips)
+	prevGs := te.game.GetGameState()
+	stackSize := gamePlayerStackSize(prevGs, gamePlayerIdx)
+	chipLevel := gamePlayerWager(prevGs, gamePlayerIdx) + chips
+	if err := te.validateChipLevel(gamePlayerIdx, chipLevel, false); err != nil {
+		return err
+	}
+
+	gs, err := te.game.Bet(gamePlayerIdx, chips)
 	if err == nil {
+		te.table.recordFixedLimitWager(prevGs, chipLevel)
 		playerIdx := te.table.State.GamePlayerIndexes[gamePlayerIdx]
 
 		playerState := te.table.State.PlayerStates[playerIdx]
 		playerState.GameStatistics.ActionTimes++
-		if te.game.GetGameState().Status.CurrentRaiser == gamePlayerIdx {
+		if gs.Status.CurrentRaiser == gamePlayerIdx {
 			playerState.GameStatistics.RaiseTimes++
 		}
+
+		te.emitGamePlayerActionEvent(te.newGamePlayerAction(gs, gamePlayerIdx, WagerAction_Bet, stackSize-gamePlayerStackSize(gs, gamePlayerIdx)))
 	}
 	return err
 }
@@ -382,13 +685,22 @@ func (te *tableEngine) PlayerRaise(playerID string, chipLevel int64) error {
 		return err
 	}
 
-	_, err := te.game.Raise(gamePlayerIdx, chipLevel)
+	prevGs := te.game.GetGameState()
+	stackSize := gamePlayerStackSize(prevGs, gamePlayerIdx)
+	if err := te.validateChipLevel(gamePlayerIdx, chipLevel, false); err != nil {
+		return err
+	}
+
+	gs, err := te.game.Raise(gamePlayerIdx, chipLevel)
 	if err == nil {
+		te.table.recordFixedLimitWager(prevGs, chipLevel)
 		playerIdx := te.table.State.GamePlayerIndexes[gamePlayerIdx]
 
 		playerState := te.table.State.PlayerStates[playerIdx]
 		playerState.GameStatistics.ActionTimes++
 		playerState.GameStatistics.RaiseTimes++
+
+		te.emitGamePlayerActionEvent(te.newGamePlayerAction(gs, gamePlayerIdx, WagerAction_Raise, stackSize-gamePlayerStackSize(gs, gamePlayerIdx)))
 	}
 	return err
 }
@@ -402,13 +714,16 @@ func (te *tableEngine) PlayerCall(playerID string) error {
 		return err
 	}
 
-	_, err := te.game.Call(gamePlayerIdx)
+	stackSize := gamePlayerStackSize(te.game.GetGameState(), gamePlayerIdx)
+	gs, err := te.game.Call(gamePlayerIdx)
 	if err == nil {
 		playerIdx := te.table.State.GamePlayerIndexes[gamePlayerIdx]
 
 		playerState := te.table.State.PlayerStates[playerIdx]
 		playerState.GameStatistics.ActionTimes++
 		playerState.GameStatistics.CallTimes++
+
+		te.emitGamePlayerActionEvent(te.newGamePlayerAction(gs, gamePlayerIdx, WagerAction_Call, stackSize-gamePlayerStackSize(gs, gamePlayerIdx)))
 	}
 	return err
 }
@@ -422,15 +737,25 @@ func (te *tableEngine) PlayerAllin(playerID string) error {
 		return err
 	}
 
-	_, err := te.game.Allin(gamePlayerIdx)
+	prevGs := te.game.GetGameState()
+	stackSize := gamePlayerStackSize(prevGs, gamePlayerIdx)
+	chipLevel := gamePlayerWager(prevGs, gamePlayerIdx) + stackSize
+	if err := te.validateChipLevel(gamePlayerIdx, chipLevel, true); err != nil {
+		return err
+	}
+
+	gs, err := te.game.Allin(gamePlayerIdx)
 	if err == nil {
+		te.table.recordFixedLimitWager(prevGs, chipLevel)
 		playerIdx := te.table.State.GamePlayerIndexes[gamePlayerIdx]
 
 		playerState := te.table.State.PlayerStates[playerIdx]
 		playerState.GameStatistics.ActionTimes++
-		if te.game.GetGameState().Status.CurrentRaiser == gamePlayerIdx {
+		if gs.Status.CurrentRaiser == gamePlayerIdx {
 			playerState.GameStatistics.RaiseTimes++
 		}
+
+		te.emitGamePlayerActionEvent(te.newGamePlayerAction(gs, gamePlayerIdx, WagerAction_AllIn, stackSize-gamePlayerStackSize(gs, gamePlayerIdx)))
 	}
 	return err
 }
@@ -439,23 +764,17 @@ func (te *tableEngine) PlayerCheck(playerID string) error {
 	te.lock.Lock()
 	defer te.lock.Unlock()
 
-	gamePlayerIdx := te.table.FindGamePlayerIdx(playerID)
-	if err := te.validateGameMove(gamePlayerIdx); err != nil {
-		return err
-	}
+	return te.playerCheck(playerID)
+}
 
-	_, err := te.game.Check(gamePlayerIdx)
-	if err == nil {
-		playerIdx := te.table.State.GamePlayerIndexes[gamePlayerIdx]
+func (te *tableEngine) PlayerFold(playerID string) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
 
-		playerState := te.table.State.PlayerStates[playerIdx]
-		playerState.GameStatistics.ActionTimes++
-		playerState.GameStatistics.CheckTimes++
-	}
-	return err
+	return te.playerFold(playerID)
 }
 
-func (te *tableEngine) PlayerFold(playerID string) error {
+func (te *tableEngine) PlayerPass(playerID string) error {
 	te.lock.Lock()
 	defer te.lock.Unlock()
 
@@ -464,19 +783,11 @@ func (te *tableEngine) PlayerFold(playerID string) error {
 		return err
 	}
 
-	_, err := te.game.Fold(gamePlayerIdx)
-	if err == nil {
-		playerIdx := te.table.State.GamePlayerIndexes[gamePlayerIdx]
-
-		playerState := te.table.State.PlayerStates[playerIdx]
-		playerState.GameStatistics.ActionTimes++
-		playerState.GameStatistics.IsFold = true
-		playerState.GameStatistics.FoldRound = te.game.GetGameState().Status.Round
-	}
+	_, err := te.game.Pass(gamePlayerIdx)
 	return err
 }
 
-func (te *tableEngine) PlayerPass(playerID string) error {
+func (te *tableEngine) PlayerExtendTime(playerID string, duration int) error {
 	te.lock.Lock()
 	defer te.lock.Unlock()
 
@@ -485,8 +796,34 @@ func (te *tableEngine) PlayerPass(playerID string) error {
 		return err
 	}
 
-	_, err := te.game.Pass(gamePlayerIdx)
-	return err
+	// only the player who is counting down can extend time
+	if te.table.State.GameState.Status.CurrentPlayer != gamePlayerIdx || te.table.State.ActionEndAt == UnsetValue {
+		return ErrTablePlayerInvalidAction
+	}
+
+	if duration <= 0 {
+		return ErrTablePlayerInvalidAction
+	}
+
+	playerIdx := te.table.State.GamePlayerIndexes[gamePlayerIdx]
+	playerState := te.table.State.PlayerStates[playerIdx]
+	if playerState.TimeBank <= 0 {
+		return ErrTablePlayerNoTimeBank
+	}
+
+	if duration > playerState.TimeBank {
+		duration = playerState.TimeBank
+	}
+
+	if !te.tb.Extend(time.Duration(duration) * time.Second) {
+		return ErrTablePlayerInvalidAction
+	}
+
+	playerState.TimeBank -= duration
+	te.table.State.ActionEndAt += int64(duration)
+
+	te.emitEvent(TableEventKind_PlayerTimeExtended, playerID, duration)
+	return nil
 }
 
 func (te *tableEngine) calcLeavePlayers(status TableStateStatus, leavePlayerIDs []string, currentPlayers []*TablePlayerState, tableMaxSeatCount int) ([]*TablePlayerState, []int, []int) {
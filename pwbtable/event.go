@@ -5,18 +5,91 @@ import (
 	"time"
 )
 
-func (te *tableEngine) emitEvent(eventName string, playerID string) {
+func (te *tableEngine) emitEvent(kind TableEventKind, playerID string, payload interface{}) {
 	// refresh table
 	te.table.UpdateAt = time.Now().Unix()
 	te.table.UpdateSerial++
 
 	// emit event
-	fmt.Printf("->[Table %s][#%d][%d][%s] emit Event: %s\n", te.table.ID, te.table.UpdateSerial, te.table.State.GameCount, playerID, eventName)
+	fmt.Printf("->[Table %s][#%d][%d][%s] emit Event: %s\n", te.table.ID, te.table.UpdateSerial, te.table.State.GameCount, playerID, kind)
+	te.saveSnapshot(kind)
+	te.emitTableStateEvent(kind)
+	te.events.publish(te.newTableEvent(kind, playerID, payload))
 	te.onTableUpdated(te.table)
+	te.emitTableViewEvents()
 }
 
-func (te *tableEngine) emitErrorEvent(eventName string, playerID string, err error) {
+func (te *tableEngine) emitTableViewEvents() {
+	if te.options == nil || !te.options.EnablePlayerViews {
+		return
+	}
+
+	for _, player := range te.table.State.PlayerStates {
+		if view, err := te.table.ViewFor(player.PlayerID); err == nil {
+			te.onTableViewUpdated(player.PlayerID, view)
+		}
+	}
+
+	if view, err := te.table.SpectatorView(); err == nil {
+		te.onTableViewUpdated("", view)
+	}
+}
+
+// saveSnapshot writes synchronously, so the snapshot never lags behind what players have seen when the process crashes.
+func (te *tableEngine) saveSnapshot(kind TableEventKind) {
+	if te.tableStore == nil {
+		return
+	}
+
+	if err := te.tableStore.SaveTable(te.table); err != nil {
+		te.emitErrorEvent(kind, "", err)
+	}
+}
+
+func (te *tableEngine) emitErrorEvent(kind TableEventKind, playerID string, err error) {
 	// emit event
-	fmt.Printf("->[Table %s][#%d][%d][%s] emit ERROR Event: %s, Error: %v\n", te.table.ID, te.table.UpdateSerial, te.table.State.GameCount, playerID, eventName, err)
+	fmt.Printf("->[Table %s][#%d][%d][%s] emit ERROR Event: %s, Error: %v\n", te.table.ID, te.table.UpdateSerial, te.table.State.GameCount, playerID, kind, err)
+	event := te.newTableEvent(kind, playerID, nil)
+	event.Error = err.Error()
+	te.events.publish(event)
 	te.onTableErrorUpdated(te.table, err)
 }
+
+func (te *tableEngine) emitTableStateEvent(kind TableEventKind) {
+	// only status transitions are emitted
+	if te.table.State.Status == te.lastStatus {
+		return
+	}
+	te.lastStatus = te.table.State.Status
+
+	te.onTableStateUpdated(string(kind), te.table)
+}
+
+func (te *tableEngine) newTableEvent(kind TableEventKind, playerID string, payload interface{}) *TableEvent {
+	return &TableEvent{
+		Kind:      kind,
+		Serial:    te.table.UpdateSerial,
+		TableID:   te.table.ID,
+		GameCount: te.table.State.GameCount,
+		PlayerID:  playerID,
+		Payload:   payload,
+		CreatedAt: time.Now().Unix(),
+	}
+}
+
+func (te *tableEngine) emitTablePlayerStateEvent(playerState *TablePlayerState) {
+	te.onTablePlayerStateUpdated(te.table.Meta.CompetitionID, te.table.ID, playerState)
+}
+
+func (te *tableEngine) emitTablePlayerReservedEvent(playerState *TablePlayerState) {
+	te.onTablePlayerReserved(te.table.Meta.CompetitionID, te.table.ID, playerState)
+}
+
+func (te *tableEngine) emitGamePlayerActionEvent(gameAction TablePlayerGameAction) {
+	if te.handHistoryRecorder != nil {
+		te.handHistoryRecorder.RecordPlayerAction(gameAction)
+	}
+	te.resetActionTimeouts(gameAction.PlayerID)
+
+	te.onGamePlayerActionUpdated(gameAction)
+}
@@ -25,6 +25,7 @@ type Game interface {
 	// Others
 	GetGameState() *pokerface.GameState
 	Start() (*pokerface.GameState, error)
+	Resume(gs *pokerface.GameState) (*pokerface.GameState, error)
 	Next() (*pokerface.GameState, error)
 
 	// Group Actions
@@ -32,7 +33,7 @@ type Game interface {
 	PayAnte() (*pokerface.GameState, error)
 	PayBlinds() (*pokerface.GameState, error)
 
-	// Single Actions
+	// Single Actions, which return the game state produced by the action
 	Ready(playerIdx int) (*pokerface.GameState, error)
 	Pay(playerIdx int, chips int64) (*pokerface.GameState, error)
 	Pass(playerIdx int) (*pokerface.GameState, error)
@@ -102,6 +103,14 @@ func (g *game) Start() (*pokerface.GameState, error) {
 	return g.GetGameState(), nil
 }
 
+// Resume continues a game from a restored state instead of creating a new one.
+func (g *game) Resume(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	g.runGameStateUpdater()
+
+	g.updateGameState(gs)
+	return g.GetGameState(), nil
+}
+
 func (g *game) Next() (*pokerface.GameState, error) {
 	gs, err := g.backend.Next(g.gs)
 	if err != nil {
@@ -176,7 +185,7 @@ func (g *game) Pay(playerIdx int, chips int64) (*pokerface.GameState, error) {
 	}
 
 	g.updateGameState(gs)
-	return g.GetGameState(), nil
+	return gs, nil
 }
 
 func (g *game) Pass(playerIdx int) (*pokerface.GameState, error) {
@@ -190,7 +199,7 @@ func (g *game) Pass(playerIdx int) (*pokerface.GameState, error) {
 	}
 
 	g.updateGameState(gs)
-	return g.GetGameState(), nil
+	return gs, nil
 }
 
 func (g *game) Fold(playerIdx int) (*pokerface.GameState, error) {
@@ -204,7 +213,7 @@ func (g *game) Fold(playerIdx int) (*pokerface.GameState, error) {
 	}
 
 	g.updateGameState(gs)
-	return g.GetGameState(), nil
+	return gs, nil
 }
 
 func (g *game) Check(playerIdx int) (*pokerface.GameState, error) {
@@ -218,7 +227,7 @@ func (g *game) Check(playerIdx int) (*pokerface.GameState, error) {
 	}
 
 	g.updateGameState(gs)
-	return g.GetGameState(), nil
+	return gs, nil
 }
 
 func (g *game) Call(playerIdx int) (*pokerface.GameState, error) {
@@ -232,7 +241,7 @@ func (g *game) Call(playerIdx int) (*pokerface.GameState, error) {
 	}
 
 	g.updateGameState(gs)
-	return g.GetGameState(), nil
+	return gs, nil
 }
 
 func (g *game) Allin(playerIdx int) (*pokerface.GameState, error) {
@@ -246,7 +255,7 @@ func (g *game) Allin(playerIdx int) (*pokerface.GameState, error) {
 	}
 
 	g.updateGameState(gs)
-	return g.GetGameState(), nil
+	return gs, nil
 }
 
 func (g *game) Bet(playerIdx int, chips int64) (*pokerface.GameState, error) {
@@ -260,7 +269,7 @@ func (g *game) Bet(playerIdx int, chips int64) (*pokerface.GameState, error) {
 	}
 
 	g.updateGameState(gs)
-	return g.GetGameState(), nil
+	return gs, nil
 }
 
 func (g *game) Raise(playerIdx int, chipLevel int64) (*pokerface.GameState, error) {
@@ -274,7 +283,7 @@ func (g *game) Raise(playerIdx int, chipLevel int64) (*pokerface.GameState, erro
 	}
 
 	g.updateGameState(gs)
-	return g.GetGameState(), nil
+	return gs, nil
 }
 
 func (g *game) validatePlayMove(playerIdx int) error {
@@ -0,0 +1,357 @@
+package pwbtable
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/pokerface"
+)
+
+var (
+	ErrHandHistoryNotFound = errors.New("hand history: not found")
+	ErrHandHistoryDisabled = errors.New("hand history: recorder is not enabled")
+)
+
+type HandHistory struct {
+	GameID        string               `json:"game_id"`
+	CompetitionID string               `json:"competition_id"`
+	TableID       string               `json:"table_id"`
+	GameCount     int                  `json:"game_count"`
+	Rule          string               `json:"rule"`
+	Limit         string               `json:"limit"`
+	MaxSeatCount  int                  `json:"max_seat_count"`
+	DealerSeat    int                  `json:"dealer_seat"`
+	Blind         TableBlindState      `json:"blind"`
+	StartAt       int64                `json:"start_at"`
+	EndAt         int64                `json:"end_at"`
+	Seats         []*HandHistorySeat   `json:"seats"`
+	Rounds        []*HandHistoryRound  `json:"rounds"`
+	Board         []string             `json:"board"`
+	Pots          []*HandHistoryPot    `json:"pots"`
+	Results       []*HandHistoryResult `json:"results"`
+}
+
+type HandHistorySeat struct {
+	Seat          int      `json:"seat"`
+	PlayerID      string   `json:"player_id"`
+	GamePlayerIdx int      `json:"game_player_idx"`
+	Positions     []string `json:"positions"`
+	Stack         int64    `json:"stack"`
+	HoleCards     []string `json:"hole_cards"`
+}
+
+type HandHistoryRound struct {
+	Round   string               `json:"round"`
+	Board   []string             `json:"board"`
+	Actions []*HandHistoryAction `json:"actions"`
+}
+
+type HandHistoryAction struct {
+	PlayerID  string `json:"player_id"`
+	Seat      int    `json:"seat"`
+	Action    string `json:"action"`
+	Chips     int64  `json:"chips"`
+	StackSize int64  `json:"stack_size"`
+	UpdateAt  int64  `json:"update_at"`
+}
+
+type HandHistoryPot struct {
+	PotIdx  int                  `json:"pot_idx"`        // pots of hi/lo game are split into halves
+	Half    string               `json:"half,omitempty"` // high or low half of hi/lo game
+	Total   int64                `json:"total"`
+	Rake    int64                `json:"rake"`
+	Winners []*HandHistoryWinner `json:"winners"`
+}
+
+type HandHistoryWinner struct {
+	PlayerID string `json:"player_id"`
+	Seat     int    `json:"seat"`
+	Chips    int64  `json:"chips"`
+}
+
+type HandHistoryResult struct {
+	PlayerID    string                     `json:"player_id"`
+	Seat        int                        `json:"seat"`
+	Final       int64                      `json:"final"`
+	Changed     int64                      `json:"changed"`
+	IsFold      bool                       `json:"is_fold"`
+	Combination *pokerface.CombinationInfo `json:"combination,omitempty"`
+}
+
+type HandHistoryRecorder interface {
+	RecordGameStarted(table *Table, gs *pokerface.GameState)
+	RecordGameStateUpdated(gs *pokerface.GameState)
+	RecordPlayerAction(gameAction TablePlayerGameAction)
+	RecordGameSettled(table *Table, gs *pokerface.GameState)
+	HandHistory(gameID string) (*HandHistory, error)
+}
+
+type handHistoryRecorder struct {
+	mu       sync.RWMutex
+	maxHands int
+	gameIDs  []string
+	hands    map[string]*HandHistory
+}
+
+// NewHandHistoryRecorder keeps the latest maxHands hands in memory, 0 means unlimited.
+func NewHandHistoryRecorder(maxHands int) HandHistoryRecorder {
+	return &handHistoryRecorder{
+		maxHands: maxHands,
+		gameIDs:  make([]string, 0),
+		hands:    make(map[string]*HandHistory),
+	}
+}
+
+func (r *handHistoryRecorder) RecordGameStarted(table *Table, gs *pokerface.GameState) {
+	hh := &HandHistory{
+		GameID:        gs.GameID,
+		CompetitionID: table.Meta.CompetitionID,
+		TableID:       table.ID,
+		GameCount:     table.State.GameCount,
+		Rule:          table.Meta.Rule,
+		Limit:         table.Meta.BettingLimit(),
+		MaxSeatCount:  table.Meta.TableMaxSeatCount,
+		DealerSeat:    table.State.CurrentDealerSeat,
+		Blind:         *table.State.BlindState,
+		StartAt:       time.Now().Unix(),
+		EndAt:         UnsetValue,
+		Seats:         make([]*HandHistorySeat, 0),
+		Rounds:        make([]*HandHistoryRound, 0),
+		Board:         make([]string, 0),
+		Pots:          make([]*HandHistoryPot, 0),
+		Results:       make([]*HandHistoryResult, 0),
+	}
+
+	for gamePlayerIdx, playerIdx := range table.State.GamePlayerIndexes {
+		player := table.State.PlayerStates[playerIdx]
+		hh.Seats = append(hh.Seats, &HandHistorySeat{
+			Seat:          player.Seat,
+			PlayerID:      player.PlayerID,
+			GamePlayerIdx: gamePlayerIdx,
+			Positions:     player.Positions,
+			Stack:         player.Bankroll,
+			HoleCards:     make([]string, 0),
+		})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.hands[hh.GameID] = hh
+	r.gameIDs = append(r.gameIDs, hh.GameID)
+	if r.maxHands > 0 && len(r.gameIDs) > r.maxHands {
+		delete(r.hands, r.gameIDs[0])
+		r.gameIDs = r.gameIDs[1:]
+	}
+
+	r.updateCards(hh, gs)
+}
+
+func (r *handHistoryRecorder) RecordGameStateUpdated(gs *pokerface.GameState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hh, exist := r.hands[gs.GameID]
+	if !exist {
+		return
+	}
+
+	r.updateCards(hh, gs)
+}
+
+func (r *handHistoryRecorder) RecordPlayerAction(gameAction TablePlayerGameAction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hh, exist := r.hands[gameAction.GameID]
+	if !exist {
+		return
+	}
+
+	// forced bets are paid before preflop is started
+	roundName := gameAction.Round
+	if roundName == "" {
+		roundName = GameRound_Preflop
+	}
+
+	round := hh.round(roundName)
+	round.Actions = append(round.Actions, &HandHistoryAction{
+		PlayerID:  gameAction.PlayerID,
+		Seat:      gameAction.Seat,
+		Action:    gameAction.Action,
+		Chips:     gameAction.Chips,
+		StackSize: gameAction.StackSize,
+		UpdateAt:  gameAction.UpdateAt,
+	})
+}
+
+func (r *handHistoryRecorder) RecordGameSettled(table *Table, gs *pokerface.GameState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hh, exist := r.hands[gs.GameID]
+	if !exist {
+		return
+	}
+
+	r.updateCards(hh, gs)
+	hh.EndAt = time.Now().Unix()
+
+	if gs.Result == nil {
+		return
+	}
+
+	hh.Pots = make([]*HandHistoryPot, 0)
+	for potIdx, pot := range gs.Result.Pots {
+		hhPot := &HandHistoryPot{
+			PotIdx:  potIdx,
+			Total:   pot.Total,
+			Winners: make([]*HandHistoryWinner, 0),
+		}
+		if potIdx < len(table.State.SplitPots) {
+			hhPot.PotIdx = table.State.SplitPots[potIdx].PotIdx
+			hhPot.Half = table.State.SplitPots[potIdx].Half
+		}
+		if potIdx < len(table.State.Rake.PotRakes) {
+			hhPot.Rake = table.State.Rake.PotRakes[potIdx]
+		}
+		for _, winner := range pot.Winners {
+			seat := hh.seatByGamePlayerIdx(winner.Idx)
+			if seat == nil {
+				continue
+			}
+			hhPot.Winners = append(hhPot.Winners, &HandHistoryWinner{
+				PlayerID: seat.PlayerID,
+				Seat:     seat.Seat,
+				Chips:    winner.Withdraw,
+			})
+		}
+		hh.Pots = append(hh.Pots, hhPot)
+	}
+
+	hh.Results = make([]*HandHistoryResult, 0)
+	for _, playerResult := range gs.Result.Players {
+		seat := hh.seatByGamePlayerIdx(playerResult.Idx)
+		if seat == nil {
+			continue
+		}
+
+		result := &HandHistoryResult{
+			PlayerID: seat.PlayerID,
+			Seat:     seat.Seat,
+			Final:    playerResult.Final,
+			Changed:  playerResult.Changed,
+		}
+		if player := gs.GetPlayer(playerResult.Idx); player != nil {
+			result.IsFold = player.Fold
+			result.Combination = player.Combination
+		}
+		hh.Results = append(hh.Results, result)
+	}
+}
+
+func (r *handHistoryRecorder) HandHistory(gameID string) (*HandHistory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hh, exist := r.hands[gameID]
+	if !exist {
+		return nil, ErrHandHistoryNotFound
+	}
+
+	return hh.Clone()
+}
+
+func (r *handHistoryRecorder) updateCards(hh *HandHistory, gs *pokerface.GameState) {
+	for _, player := range gs.Players {
+		seat := hh.seatByGamePlayerIdx(player.Idx)
+		if seat == nil || len(player.HoleCards) == 0 {
+			continue
+		}
+		seat.HoleCards = player.HoleCards
+	}
+
+	// record board cards of every round
+	if len(gs.Status.Board) > len(hh.Board) {
+		hh.Board = append(make([]string, 0), gs.Status.Board...)
+	}
+
+	if gs.Status.Round != "" {
+		round := hh.round(gs.Status.Round)
+		if len(hh.Board) > len(round.Board) {
+			round.Board = append(make([]string, 0), hh.Board...)
+		}
+	}
+}
+
+func (hh HandHistory) Clone() (*HandHistory, error) {
+	encoded, err := json.Marshal(hh)
+	if err != nil {
+		return nil, err
+	}
+
+	var cloneHandHistory HandHistory
+	if err := json.Unmarshal(encoded, &cloneHandHistory); err != nil {
+		return nil, err
+	}
+
+	return &cloneHandHistory, nil
+}
+
+func (hh HandHistory) GetJSON() (string, error) {
+	encoded, err := json.Marshal(hh)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+func (hh *HandHistory) round(name string) *HandHistoryRound {
+	for _, round := range hh.Rounds {
+		if round.Round == name {
+			return round
+		}
+	}
+
+	round := &HandHistoryRound{
+		Round:   name,
+		Board:   make([]string, 0),
+		Actions: make([]*HandHistoryAction, 0),
+	}
+	hh.Rounds = append(hh.Rounds, round)
+	return round
+}
+
+func (hh HandHistory) seatByGamePlayerIdx(gamePlayerIdx int) *HandHistorySeat {
+	for _, seat := range hh.Seats {
+		if seat.GamePlayerIdx == gamePlayerIdx {
+			return seat
+		}
+	}
+	return nil
+}
+
+func (hh HandHistory) seatByPlayerID(playerID string) *HandHistorySeat {
+	for _, seat := range hh.Seats {
+		if seat.PlayerID == playerID {
+			return seat
+		}
+	}
+	return nil
+}
+
+func (hh HandHistory) result(playerID string) *HandHistoryResult {
+	for _, result := range hh.Results {
+		if result.PlayerID == playerID {
+			return result
+		}
+	}
+	return nil
+}
+
+func (seat HandHistorySeat) hasPosition(position string) bool {
+	return funk.ContainsString(seat.Positions, position)
+}
@@ -0,0 +1,270 @@
+package pwbtable
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+)
+
+const handHistoryTimeFormat = "2006/01/02 15:04:05"
+
+// GetPokerStarsText exports the hand in PokerStars hand history format for tracking software.
+func (hh HandHistory) GetPokerStarsText() string {
+	var sb strings.Builder
+
+	// header
+	fmt.Fprintf(&sb, "PokerStars Hand #%d: %s (%d/%d) - %s UTC\n",
+		hh.handNumber(),
+		pokerStarsGameName(hh.Rule, hh.Limit),
+		hh.Blind.SB,
+		hh.Blind.BB,
+		time.Unix(hh.StartAt, 0).UTC().Format(handHistoryTimeFormat),
+	)
+	fmt.Fprintf(&sb, "Table '%s' %d-max Seat #%d is the button\n", hh.TableID, hh.MaxSeatCount, hh.DealerSeat+1)
+
+	for _, seat := range hh.Seats {
+		fmt.Fprintf(&sb, "Seat %d: %s (%d in chips)\n", seat.Seat+1, seat.PlayerID, seat.Stack)
+	}
+
+	// forced bets are derived from positions since they could be paid automatically
+	committed := make(map[string]int64)
+	if hh.Blind.Ante > 0 {
+		for _, seat := range hh.Seats {
+			fmt.Fprintf(&sb, "%s: posts the ante %d\n", seat.PlayerID, hh.Blind.Ante)
+		}
+	}
+	forcedBets := []struct {
+		position string
+		name     string
+		chips    int64
+	}{
+		{Position_Dealer, "dealer blind", hh.Blind.Dealer},
+		{Position_SB, "small blind", hh.Blind.SB},
+		{Position_BB, "big blind", hh.Blind.BB},
+	}
+	for _, forcedBet := range forcedBets {
+		if forcedBet.chips <= 0 {
+			continue
+		}
+		for _, seat := range hh.Seats {
+			if seat.hasPosition(forcedBet.position) {
+				fmt.Fprintf(&sb, "%s: posts %s %d\n", seat.PlayerID, forcedBet.name, forcedBet.chips)
+				committed[seat.PlayerID] += forcedBet.chips
+			}
+		}
+	}
+
+	// hole cards
+	sb.WriteString("*** HOLE CARDS ***\n")
+	for _, seat := range hh.Seats {
+		if len(seat.HoleCards) > 0 {
+			fmt.Fprintf(&sb, "Dealt to %s [%s]\n", seat.PlayerID, pokerStarsCards(seat.HoleCards))
+		}
+	}
+
+	// rounds
+	foldRounds := make(map[string]string)
+	previousBoard := make([]string, 0)
+	for _, round := range hh.Rounds {
+		if round.Round != GameRound_Preflop {
+			committed = make(map[string]int64)
+
+			header := fmt.Sprintf("*** %s ***", strings.ToUpper(round.Round))
+			if len(previousBoard) == 0 {
+				fmt.Fprintf(&sb, "%s [%s]\n", header, pokerStarsCards(round.Board))
+			} else {
+				fmt.Fprintf(&sb, "%s [%s] [%s]\n", header, pokerStarsCards(previousBoard), pokerStarsCards(round.Board[len(previousBoard):]))
+			}
+			previousBoard = round.Board
+		}
+
+		maxWager := int64(0)
+		for _, wager := range committed {
+			if wager > maxWager {
+				maxWager = wager
+			}
+		}
+
+		for _, action := range round.Actions {
+			// forced bets have been posted already
+			if action.Action == Action_Pay {
+				continue
+			}
+
+			committed[action.PlayerID] += action.Chips
+			wager := committed[action.PlayerID]
+
+			switch action.Action {
+			case WagerAction_Fold:
+				foldRounds[action.PlayerID] = round.Round
+				fmt.Fprintf(&sb, "%s: folds\n", action.PlayerID)
+			case WagerAction_Check:
+				fmt.Fprintf(&sb, "%s: checks\n", action.PlayerID)
+			case WagerAction_Call:
+				fmt.Fprintf(&sb, "%s: calls %d\n", action.PlayerID, action.Chips)
+			case WagerAction_Bet:
+				fmt.Fprintf(&sb, "%s: bets %d\n", action.PlayerID, action.Chips)
+			case WagerAction_Raise:
+				fmt.Fprintf(&sb, "%s: raises %d to %d\n", action.PlayerID, wager-maxWager, wager)
+			case WagerAction_AllIn:
+				if wager <= maxWager {
+					fmt.Fprintf(&sb, "%s: calls %d and is all-in\n", action.PlayerID, action.Chips)
+				} else if maxWager == 0 {
+					fmt.Fprintf(&sb, "%s: bets %d and is all-in\n", action.PlayerID, action.Chips)
+				} else {
+					fmt.Fprintf(&sb, "%s: raises %d to %d and is all-in\n", action.PlayerID, wager-maxWager, wager)
+				}
+			}
+
+			if wager > maxWager {
+				maxWager = wager
+			}
+		}
+	}
+
+	// show down
+	isShowdown := false
+	for _, result := range hh.Results {
+		if result.IsFold || result.Combination == nil {
+			continue
+		}
+		seat := hh.seatByPlayerID(result.PlayerID)
+		if seat == nil || len(seat.HoleCards) == 0 {
+			continue
+		}
+
+		if !isShowdown {
+			sb.WriteString("*** SHOW DOWN ***\n")
+			isShowdown = true
+		}
+		fmt.Fprintf(&sb, "%s: shows [%s] (%s)\n", result.PlayerID, pokerStarsCards(seat.HoleCards), strings.ReplaceAll(result.Combination.Type, "_", " "))
+	}
+
+	collected := make(map[string]int64)
+	totalPot := int64(0)
+	rake := int64(0)
+	potCount := 0
+	if len(hh.Pots) > 0 {
+		potCount = hh.Pots[len(hh.Pots)-1].PotIdx + 1
+	}
+	for _, pot := range hh.Pots {
+		totalPot += pot.Total
+		rake += pot.Rake
+
+		potName := "pot"
+		if potCount > 1 {
+			if pot.PotIdx == 0 {
+				potName = "main pot"
+			} else {
+				potName = fmt.Sprintf("side pot-%d", pot.PotIdx)
+			}
+		}
+
+		for _, winner := range pot.Winners {
+			collected[winner.PlayerID] += winner.Chips
+			fmt.Fprintf(&sb, "%s collected %d from %s\n", winner.PlayerID, winner.Chips, potName)
+		}
+	}
+
+	// summary
+	sb.WriteString("*** SUMMARY ***\n")
+	fmt.Fprintf(&sb, "Total pot %d | Rake %d\n", totalPot, rake)
+	if len(hh.Board) > 0 {
+		fmt.Fprintf(&sb, "Board [%s]\n", pokerStarsCards(hh.Board))
+	}
+
+	for _, seat := range hh.Seats {
+		fmt.Fprintf(&sb, "Seat %d: %s%s %s\n", seat.Seat+1, seat.PlayerID, pokerStarsPositionName(seat), hh.pokerStarsSeatSummary(seat, foldRounds, collected))
+	}
+
+	return sb.String()
+}
+
+func (hh HandHistory) pokerStarsSeatSummary(seat *HandHistorySeat, foldRounds map[string]string, collected map[string]int64) string {
+	if foldRound, exist := foldRounds[seat.PlayerID]; exist {
+		if foldRound == GameRound_Preflop {
+			return "folded before Flop"
+		}
+		return fmt.Sprintf("folded on the %s", strings.ToUpper(foldRound[:1])+foldRound[1:])
+	}
+
+	result := hh.result(seat.PlayerID)
+	isShown := result != nil && result.Combination != nil && len(seat.HoleCards) > 0
+	chips, isWinner := collected[seat.PlayerID]
+
+	switch {
+	case isShown && isWinner:
+		return fmt.Sprintf("showed [%s] and won (%d)", pokerStarsCards(seat.HoleCards), chips)
+	case isShown:
+		return fmt.Sprintf("showed [%s] and lost", pokerStarsCards(seat.HoleCards))
+	case isWinner:
+		return fmt.Sprintf("collected (%d)", chips)
+	}
+	return "mucked"
+}
+
+func (hh HandHistory) handNumber() uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(hh.GameID))
+	return h.Sum64() >> 1
+}
+
+func pokerStarsGameName(rule, limit string) string {
+	limitName := "No Limit"
+	switch limit {
+	case BettingLimit_PotLimit:
+		limitName = "Pot Limit"
+	case BettingLimit_FixedLimit:
+		limitName = "Limit"
+	}
+
+	switch rule {
+	case CompetitionRule_Omaha:
+		return "Omaha " + limitName
+	case CompetitionRule_OmahaHiLo:
+		return "Omaha Hi/Lo " + limitName
+	case CompetitionRule_Omaha5:
+		return "5 Card Omaha " + limitName
+	case CompetitionRule_Omaha6:
+		return "6 Card Omaha " + limitName
+	case CompetitionRule_ShortDeck:
+		return "Hold'em Short Deck " + limitName
+	}
+	return "Hold'em " + limitName
+}
+
+func pokerStarsPositionName(seat *HandHistorySeat) string {
+	switch {
+	case seat.hasPosition(Position_Dealer) && seat.hasPosition(Position_SB):
+		return " (button) (small blind)"
+	case seat.hasPosition(Position_Dealer):
+		return " (button)"
+	case seat.hasPosition(Position_SB):
+		return " (small blind)"
+	case seat.hasPosition(Position_BB):
+		return " (big blind)"
+	}
+	return ""
+}
+
+// pokerStarsCards converts cards like "SA" or "H9" into "As" or "9h".
+func pokerStarsCards(cards []string) string {
+	converted := make([]string, 0, len(cards))
+	for _, card := range cards {
+		converted = append(converted, pokerStarsCard(card))
+	}
+	return strings.Join(converted, " ")
+}
+
+func pokerStarsCard(card string) string {
+	if len(card) != 2 {
+		return card
+	}
+
+	suits := "SHDC"
+	if strings.ContainsRune(suits, rune(card[0])) {
+		return strings.ToUpper(card[1:]) + strings.ToLower(card[:1])
+	}
+	return strings.ToUpper(card[:1]) + strings.ToLower(card[1:])
+}
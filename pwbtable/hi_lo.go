@@ -0,0 +1,231 @@
+package pwbtable
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/settlement"
+)
+
+const (
+	PotHalf_High = "high"
+	PotHalf_Low  = "low"
+)
+
+// TableSplitPot describes a pot of hi/lo game result, every pot is split into high half and low half unless high hand scoops.
+type TableSplitPot struct {
+	PotIdx int      `json:"pot_idx"` // index of pot before split
+	Half   string   `json:"half"`
+	Cards  []string `json:"cards,omitempty"` // the winning low hand
+}
+
+// settleHiLo splits pots of Omaha Hi/Lo before chips are settled to bankrolls.
+func (te *tableEngine) settleHiLo() {
+	te.table.State.SplitPots = nil
+	if te.table.Meta.Rule != CompetitionRule_OmahaHiLo {
+		return
+	}
+
+	// high hands take the whole pots if they are not able to be split
+	splitPots, err := SplitHiLoResult(te.table.State.GameState)
+	if err != nil {
+		te.emitErrorEvent(TableEventKind_TableGameSettled, "", err)
+		return
+	}
+	te.table.State.SplitPots = splitPots
+}
+
+// SplitHiLoResult replaces pots of game result by high and low halves, the odd chip goes to high half.
+// Game result is left unchanged if its pots do not match pots of game.
+func SplitHiLoResult(gs *pokerface.GameState) ([]TableSplitPot, error) {
+	result := gs.Result
+	if result == nil {
+		return nil, nil
+	}
+
+	if len(gs.Status.Pots) != len(result.Pots) {
+		return nil, ErrTableHiLoPotMismatch
+	}
+
+	withdraws := make(map[int]int64)
+	pots := make([]*settlement.PotResult, 0, len(result.Pots))
+	splitPots := make([]TableSplitPot, 0, len(result.Pots))
+	for potIdx, pot := range result.Pots {
+		for _, winner := range pot.Winners {
+			withdraws[winner.Idx] -= winner.Withdraw
+		}
+
+		lowWinners, lowCards := bestLowWinners(gs, gs.Status.Pots[potIdx].Contributors)
+		if len(lowWinners) == 0 || len(pot.Winners) == 0 {
+			pots = append(pots, pot)
+			splitPots = append(splitPots, TableSplitPot{PotIdx: potIdx, Half: PotHalf_High})
+			for _, winner := range pot.Winners {
+				withdraws[winner.Idx] += winner.Withdraw
+			}
+			continue
+		}
+
+		highWinners := make([]int, 0, len(pot.Winners))
+		for _, winner := range pot.Winners {
+			highWinners = append(highWinners, winner.Idx)
+		}
+
+		lowTotal := pot.Total / 2
+		highPot := splitPotResult(pot.Total-lowTotal, highWinners, len(gs.Players))
+		lowPot := splitPotResult(lowTotal, lowWinners, len(gs.Players))
+		for _, half := range []*settlement.PotResult{highPot, lowPot} {
+			for _, winner := range half.Winners {
+				withdraws[winner.Idx] += winner.Withdraw
+			}
+		}
+
+		pots = append(pots, highPot, lowPot)
+		splitPots = append(splitPots,
+			TableSplitPot{PotIdx: potIdx, Half: PotHalf_High},
+			TableSplitPot{PotIdx: potIdx, Half: PotHalf_Low, Cards: lowCards},
+		)
+	}
+
+	result.Pots = pots
+	for _, player := range result.Players {
+		player.Final += withdraws[player.Idx]
+		player.Changed += withdraws[player.Idx]
+	}
+
+	return splitPots, nil
+}
+
+// splitPotResult shares chips among winners, odd chips go to the first winner left of the button.
+func splitPotResult(total int64, winnerIdxs []int, playerCount int) *settlement.PotResult {
+	sort.Slice(winnerIdxs, func(i, j int) bool {
+		return (winnerIdxs[i]+playerCount-1)%playerCount < (winnerIdxs[j]+playerCount-1)%playerCount
+	})
+
+	pot := &settlement.PotResult{
+		Total:   total,
+		Winners: make([]*settlement.Winner, 0, len(winnerIdxs)),
+	}
+
+	share := total / int64(len(winnerIdxs))
+	for _, idx := range winnerIdxs {
+		pot.Winners = append(pot.Winners, &settlement.Winner{Idx: idx, Withdraw: share})
+	}
+	pot.Winners[0].Withdraw += total - share*int64(len(winnerIdxs))
+
+	return pot
+}
+
+// bestLowWinners returns players who have the best qualifying low among contributors still in hand.
+func bestLowWinners(gs *pokerface.GameState, contributors map[int]int64) ([]int, []string) {
+	winners := make([]int, 0)
+	var bestRanks []int
+	var bestCards []string
+	for _, player := range gs.Players {
+		if player.Fold {
+			continue
+		}
+
+		if _, ok := contributors[player.Idx]; !ok {
+			continue
+		}
+
+		ranks, cards := BestLowHand(player.HoleCards, gs.Status.Board)
+		if ranks == nil {
+			continue
+		}
+
+		switch cmp := compareLowRanks(ranks, bestRanks); {
+		case bestRanks == nil || cmp < 0:
+			winners = []int{player.Idx}
+			bestRanks = ranks
+			bestCards = cards
+		case cmp == 0:
+			winners = append(winners, player.Idx)
+		}
+	}
+
+	return winners, bestCards
+}
+
+// BestLowHand returns ranks from high to low and cards of the best eight-or-better low made of exactly two hole cards and three board cards,
+// ranks are nil if no low qualifies.
+func BestLowHand(holeCards, board []string) ([]int, []string) {
+	var bestRanks []int
+	var bestCards []string
+	for i := 0; i < len(holeCards); i++ {
+		for j := i + 1; j < len(holeCards); j++ {
+			for a := 0; a < len(board); a++ {
+				for b := a + 1; b < len(board); b++ {
+					for c := b + 1; c < len(board); c++ {
+						cards := []string{holeCards[i], holeCards[j], board[a], board[b], board[c]}
+						ranks := lowRanks(cards)
+						if ranks != nil && (bestRanks == nil || compareLowRanks(ranks, bestRanks) < 0) {
+							bestRanks = ranks
+							bestCards = cards
+						}
+					}
+				}
+			}
+		}
+	}
+	return bestRanks, bestCards
+}
+
+// lowRanks returns ranks from high to low if cards make a qualifying low: five different ranks of eight or lower, ace is one.
+func lowRanks(cards []string) []int {
+	ranks := make([]int, 0, len(cards))
+	seen := make(map[int]bool)
+	for _, card := range cards {
+		rank := cardLowRank(card)
+		if rank == 0 || seen[rank] {
+			return nil
+		}
+		seen[rank] = true
+		ranks = append(ranks, rank)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(ranks)))
+	return ranks
+}
+
+// compareLowRanks returns negative if a is the better low, a nil hand is the worst.
+func compareLowRanks(a, b []int) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case b == nil:
+		return -1
+	case a == nil:
+		return 1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+	return 0
+}
+
+// cardLowRank returns rank of card for low hand, 0 if the card is higher than eight.
+func cardLowRank(card string) int {
+	card = strings.ToUpper(card)
+	if len(card) != 2 {
+		return 0
+	}
+
+	// card is either suit first or rank first
+	rank := card[1]
+	if strings.ContainsRune("SHDC", rune(card[1])) {
+		rank = card[0]
+	}
+
+	switch {
+	case rank == 'A':
+		return 1
+	case rank >= '2' && rank <= '8':
+		return int(rank - '0')
+	}
+	return 0
+}
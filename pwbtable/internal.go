@@ -2,11 +2,14 @@ package pwbtable
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/weedbox/pokerface"
 	"github.com/weedbox/syncsaga"
+	"github.com/weedbox/timebank"
 )
 
 func (te *tableEngine) delay(interval int, fn func() error) error {
@@ -14,7 +17,9 @@ func (te *tableEngine) delay(interval int, fn func() error) error {
 	var wg sync.WaitGroup
 	wg.Add(1)
 
-	te.tb.NewTask(time.Duration(interval)*time.Second, func(isCancelled bool) {
+	// te.tb is reserved for player action timers
+	tb := timebank.NewTimeBank()
+	tb.NewTask(time.Duration(interval)*time.Second, func(isCancelled bool) {
 		defer wg.Done()
 
 		if isCancelled {
@@ -29,21 +34,36 @@ func (te *tableEngine) delay(interval int, fn func() error) error {
 }
 
 func (te *tableEngine) updateGameState(gs *pokerface.GameState) {
+	te.table.applyBettingLimit(gs)
 	te.table.State.GameState = gs
 
+	if te.handHistoryRecorder != nil {
+		te.handHistoryRecorder.RecordGameStateUpdated(gs)
+	}
+
 	event, ok := pokerface.GameEventBySymbol[gs.Status.CurrentEvent]
 	if !ok {
-		te.emitErrorEvent("handle updateGameState", "", ErrGameUnknownEvent)
+		te.emitErrorEvent(TableEventKind_GameStateUpdated, "", ErrGameUnknownEvent)
 		return
 	}
 
 	switch event {
 	case pokerface.GameEvent_GameClosed:
+		te.lock.Lock()
+		te.stopActionTimer()
+		te.lock.Unlock()
+
 		if err := te.onGameClosed(); err != nil {
-			te.emitErrorEvent("onGameClosed", "", err)
+			te.emitErrorEvent(TableEventKind_TableGameSettled, "", err)
 		}
 	default:
-		te.emitEvent(gs.Status.CurrentEvent, "")
+		// action timer is shared with timeout callback and player actions
+		te.lock.Lock()
+		te.refreshActionTimer(gs)
+		te.lock.Unlock()
+
+		te.emitEvent(TableEventKind_GameStateUpdated, "", gs.Status.CurrentEvent)
+		te.runAutoMode(gs)
 	}
 }
 
@@ -61,9 +81,48 @@ func (te *tableEngine) validateGameMove(gamePlayerIdx int) error {
 	return nil
 }
 
+func (te *tableEngine) playerCheck(playerID string) error {
+	gamePlayerIdx := te.table.FindGamePlayerIdx(playerID)
+	if err := te.validateGameMove(gamePlayerIdx); err != nil {
+		return err
+	}
+
+	gs, err := te.game.Check(gamePlayerIdx)
+	if err == nil {
+		playerIdx := te.table.State.GamePlayerIndexes[gamePlayerIdx]
+
+		playerState := te.table.State.PlayerStates[playerIdx]
+		playerState.GameStatistics.ActionTimes++
+		playerState.GameStatistics.CheckTimes++
+
+		te.emitGamePlayerActionEvent(te.newGamePlayerAction(gs, gamePlayerIdx, WagerAction_Check, 0))
+	}
+	return err
+}
+
+func (te *tableEngine) playerFold(playerID string) error {
+	gamePlayerIdx := te.table.FindGamePlayerIdx(playerID)
+	if err := te.validateGameMove(gamePlayerIdx); err != nil {
+		return err
+	}
+
+	gs, err := te.game.Fold(gamePlayerIdx)
+	if err == nil {
+		playerIdx := te.table.State.GamePlayerIndexes[gamePlayerIdx]
+
+		playerState := te.table.State.PlayerStates[playerIdx]
+		playerState.GameStatistics.ActionTimes++
+		playerState.GameStatistics.IsFold = true
+		playerState.GameStatistics.FoldRound = gs.Status.Round
+
+		te.emitGamePlayerActionEvent(te.newGamePlayerAction(gs, gamePlayerIdx, WagerAction_Fold, 0))
+	}
+	return err
+}
+
 func (te *tableEngine) batchAddPlayers(players []JoinPlayer) error {
 	// decide seats
-	availableSeats, err := RandomSeats(te.table.State.SeatMap, len(players))
+	availableSeats, err := RandomSeatsWithSource(te.rand, te.table.State.SeatMap, len(players))
 	if err != nil {
 		return err
 	}
@@ -72,8 +131,8 @@ func (te *tableEngine) batchAddPlayers(players []JoinPlayer) error {
 	newSeatMap := make([]int, len(te.table.State.SeatMap))
 	copy(newSeatMap, te.table.State.SeatMap)
 	newPlayers := make([]*TablePlayerState, 0)
-	for idx, player := range players {
-		reservedSeat := player.Seat
+	for idx, joinPlayer := range players {
+		reservedSeat := joinPlayer.Seat
 
 		// add new player
 		var seat int
@@ -89,16 +148,20 @@ func (te *tableEngine) batchAddPlayers(players []JoinPlayer) error {
 
 		// update state
 		player := &TablePlayerState{
-			PlayerID:          player.PlayerID,
+			PlayerID:          joinPlayer.PlayerID,
 			Seat:              seat,
 			Positions:         []string{Position_Unknown},
 			IsParticipated:    false,
 			IsBetweenDealerBB: IsBetweenDealerBB(seat, te.table.State.CurrentDealerSeat, te.table.State.CurrentBBSeat, te.table.Meta.TableMaxSeatCount, te.table.Meta.Rule),
-			Bankroll:          player.RedeemChips,
+			Bankroll:          joinPlayer.RedeemChips,
 			IsIn:              false,
+			SitOutAt:          UnsetValue,
+			TimeBank:          te.table.Meta.TimeBank.InitialSeconds,
 			GameStatistics:    TablePlayerGameStatistics{},
 		}
+		carryPlayerState(player, joinPlayer.State)
 		newPlayers = append(newPlayers, player)
+		te.emitTablePlayerStateEvent(player)
 
 		newPlayerIdx := len(te.table.State.PlayerStates) + len(newPlayers) - 1
 		newSeatMap[seat] = newPlayerIdx
@@ -106,12 +169,29 @@ func (te *tableEngine) batchAddPlayers(players []JoinPlayer) error {
 
 	te.table.State.SeatMap = newSeatMap
 	te.table.State.PlayerStates = append(te.table.State.PlayerStates, newPlayers...)
+	for _, joinPlayer := range players {
+		te.carryPlayerEntry(joinPlayer)
+	}
 
 	te.playersAutoIn()
 
 	return nil
 }
 
+// carryPlayerState keeps time bank, sit-out and auto mode of player moved from another table.
+func carryPlayerState(playerState, movedState *TablePlayerState) {
+	if movedState == nil {
+		return
+	}
+
+	playerState.TimeBank = movedState.TimeBank
+	playerState.TimeBankHandCount = movedState.TimeBankHandCount
+	playerState.IsSittingOut = movedState.IsSittingOut
+	playerState.SitOutAt = movedState.SitOutAt
+	playerState.TimeoutCount = movedState.TimeoutCount
+	playerState.IsAutoMode = movedState.IsAutoMode
+}
+
 func (te *tableEngine) playersAutoIn() {
 	// Preparing ready group for waiting all players' join
 	te.rg.Stop()
@@ -129,12 +209,18 @@ func (te *tableEngine) playersAutoIn() {
 		for playerIdx, player := range te.table.State.PlayerStates {
 			if !player.IsIn {
 				te.table.State.PlayerStates[playerIdx].IsIn = true
+				te.emitTablePlayerStateEvent(te.table.State.PlayerStates[playerIdx])
 			}
 		}
 
+		// sit & go is not started until the table is full
+		if te.table.Meta.Mode == CompetitionMode_SitAndGo && len(te.table.State.PlayerStates) < te.table.Meta.TableMaxSeatCount {
+			return
+		}
+
 		if te.table.State.GameCount <= 0 {
 			if err := te.StartTableGame(); err != nil {
-				te.emitErrorEvent("StartTableGame", "", err)
+				te.emitErrorEvent(TableEventKind_TableGameStarted, "", err)
 			}
 		}
 	})
@@ -149,6 +235,25 @@ func (te *tableEngine) playersAutoIn() {
 	te.rg.Start()
 }
 
+func (te *tableEngine) leavePlayers(playerIDs []string) {
+	for _, playerID := range playerIDs {
+		playerIdx := te.table.FindPlayerIdx(playerID)
+		if playerIdx == UnsetValue {
+			continue
+		}
+
+		// emit the final state of leaving player
+		playerState := *te.table.State.PlayerStates[playerIdx]
+		playerState.Seat = UnsetValue
+		playerState.IsIn = false
+		te.emitTablePlayerStateEvent(&playerState)
+	}
+
+	te.recordLeftPlayers(playerIDs)
+	te.batchRemovePlayers(playerIDs)
+	te.emitEvent(TableEventKind_PlayersLeft, strings.Join(playerIDs, ","), playerIDs)
+}
+
 func (te *tableEngine) batchRemovePlayers(playerIDs []string) {
 	newPlayerStates, newSeatMap, newGamePlayerIndexes := te.calcLeavePlayers(te.table.State.Status, playerIDs, te.table.State.PlayerStates, te.table.Meta.TableMaxSeatCount)
 	te.table.State.PlayerStates = newPlayerStates
@@ -163,11 +268,12 @@ func (te *tableEngine) openGame(oldTable *Table) (*Table, error) {
 	}
 
 	cloneTable.State.Status = TableStateStatus_TableGameOpened
+	cloneTable.waitShortMissedBlinds()
 
 	for i := 0; i < len(cloneTable.State.PlayerStates); i++ {
 		playerState := cloneTable.State.PlayerStates[i]
 
-		if !playerState.IsIn {
+		if !playerState.IsIn || playerState.IsSittingOut || playerState.IsWaitingBB {
 			playerState.IsParticipated = false
 			continue
 		}
@@ -184,16 +290,17 @@ func (te *tableEngine) openGame(oldTable *Table) (*Table, error) {
 		for i := 0; i < len(cloneTable.State.PlayerStates); i++ {
 			playerState := cloneTable.State.PlayerStates[i]
 
-			if playerState.Bankroll == 0 || !playerState.IsIn {
+			if playerState.Bankroll == 0 || !playerState.IsIn || playerState.IsSittingOut {
 				continue
 			}
 
 			playerState.IsParticipated = true
 			playerState.IsBetweenDealerBB = false
+			playerState.IsWaitingBB = false
 		}
 	}
 
-	newDealerPlayerIdx := FindDealerPlayerIndex(cloneTable.State.GameCount, cloneTable.State.CurrentDealerSeat, cloneTable.Meta.TableMinPlayerCount, cloneTable.Meta.TableMaxSeatCount, cloneTable.State.PlayerStates, cloneTable.State.SeatMap)
+	newDealerPlayerIdx := FindDealerPlayerIndexWithSource(te.rand, cloneTable.State.GameCount, cloneTable.State.CurrentDealerSeat, cloneTable.Meta.TableMinPlayerCount, cloneTable.Meta.TableMaxSeatCount, cloneTable.State.PlayerStates, cloneTable.State.SeatMap)
 	newDealerTableSeatIdx := cloneTable.State.PlayerStates[newDealerPlayerIdx].Seat
 
 	for i := 0; i < len(cloneTable.State.PlayerStates); i++ {
@@ -229,6 +336,10 @@ func (te *tableEngine) openGame(oldTable *Table) (*Table, error) {
 	}
 
 	gamePlayerIndexes := FindGamePlayerIndexes(newDealerTableSeatIdx, cloneTable.State.SeatMap, cloneTable.State.PlayerStates)
+	if cloneTable.seatWaitingBBPlayer(gamePlayerIndexes) {
+		gamePlayerIndexes = FindGamePlayerIndexes(newDealerTableSeatIdx, cloneTable.State.SeatMap, cloneTable.State.PlayerStates)
+	}
+	cloneTable.trackMissedBlinds(gamePlayerIndexes)
 	if len(gamePlayerIndexes) < cloneTable.Meta.TableMinPlayerCount {
 		fmt.Printf("[DEBUG#MTT#openGame] Competition (%s), Table (%s), TableMinPlayerCount: %d, GamePlayerIndexes: %+v\n", cloneTable.Meta.CompetitionID, cloneTable.ID, cloneTable.Meta.TableMinPlayerCount, gamePlayerIndexes)
 		json, _ := cloneTable.GetJSON()
@@ -236,6 +347,7 @@ func (te *tableEngine) openGame(oldTable *Table) (*Table, error) {
 		return oldTable, ErrTableOpenGameFailed
 	}
 	cloneTable.State.GamePlayerIndexes = gamePlayerIndexes
+	cloneTable.postMissedBlinds()
 
 	positionMap := GetPlayerPositionMap(cloneTable.Meta.Rule, cloneTable.State.PlayerStates, cloneTable.State.GamePlayerIndexes)
 	for playerIdx := 0; playerIdx < len(cloneTable.State.PlayerStates); playerIdx++ {
@@ -263,27 +375,15 @@ func (te *tableEngine) openGame(oldTable *Table) (*Table, error) {
 
 func (te *tableEngine) startGame() error {
 	rule := te.table.Meta.Rule
-	blind := te.table.State.BlindState
+	rs := ruleSetOf(rule)
 
 	// create game options
-	opts := pokerface.NewStardardGameOptions()
-	opts.Deck = pokerface.NewStandardDeckCards()
-
-	if rule == CompetitionRule_ShortDeck {
-		opts = pokerface.NewShortDeckGameOptions()
-		opts.Deck = pokerface.NewShortDeckCards()
-	} else if rule == CompetitionRule_Omaha {
-		opts.HoleCardsCount = 4
-		opts.RequiredHoleCardsCount = 2
-	}
+	opts := rs.GameOptions()
+	opts.Deck = te.deckProvider.NewDeck(rule)
+	opts.Limit = te.table.Meta.BettingLimit()
 
 	// preparing blind
-	opts.Ante = blind.Ante
-	opts.Blind = pokerface.BlindSetting{
-		Dealer: blind.Dealer,
-		SB:     blind.SB,
-		BB:     blind.BB,
-	}
+	opts.Ante, opts.Blind = rs.ForcedBets(*te.table.State.BlindState)
 
 	// preparing players
 	playerSettings := make([]*pokerface.PlayerSetting, 0)
@@ -297,37 +397,129 @@ func (te *tableEngine) startGame() error {
 	opts.Players = playerSettings
 
 	// create game
-	te.game = NewGame(te.gameBackend, opts)
-	te.game.OnGameStateUpdated(func(gs *pokerface.GameState) {
-		te.updateGameState(gs)
-	})
-	te.game.OnGameErrorUpdated(func(gs *pokerface.GameState, err error) {
-		te.table.State.GameState = gs
-		go te.emitErrorEvent("OnGameErrorUpdated", "", err)
-	})
+	te.game = te.newGame(opts)
 
 	// start game
-	if _, err := te.game.Start(); err != nil {
+	gs, err := te.game.Start()
+	if err != nil {
 		return err
 	}
 
+	if te.handHistoryRecorder != nil {
+		te.handHistoryRecorder.RecordGameStarted(te.table, gs)
+	}
+
 	te.table.State.Status = TableStateStatus_TableGamePlaying
 	return nil
 }
 
+func (te *tableEngine) newGame(opts *pokerface.GameOptions) Game {
+	g := NewGame(te.gameBackend, opts)
+	g.OnGameStateUpdated(func(gs *pokerface.GameState) {
+		te.updateGameState(gs)
+	})
+	g.OnGameErrorUpdated(func(gs *pokerface.GameState, err error) {
+		te.table.State.GameState = gs
+		go te.emitErrorEvent(TableEventKind_GameStateUpdated, "", err)
+	})
+	return g
+}
+
+func (te *tableEngine) resumeGame() error {
+	gs := te.table.State.GameState
+	if gs == nil {
+		return ErrTableInvalidRestoreState
+	}
+
+	// ready group and action timer are prepared again by the restored game state
+	te.game = te.newGame(nil)
+	_, err := te.game.Resume(gs)
+	return err
+}
+
 func (te *tableEngine) settleGame() {
 	te.table.State.Status = TableStateStatus_TableGameSettled
+	te.settleDeadBlinds(te.table.State.GameState.Result)
+	te.settleHiLo()
+	gameSettlement := te.settleRake()
 
+	eliminatedPlayers := make([]*TablePlayerState, 0)
 	for _, player := range te.table.State.GameState.Result.Players {
 		playerIdx := te.table.State.GamePlayerIndexes[player.Idx]
 		playerState := te.table.State.PlayerStates[playerIdx]
-		playerState.Bankroll = player.Final
+		isBankrollChanged := playerState.Bankroll != player.Final || playerState.PendingTopUp > 0
+		playerState.Bankroll = player.Final + playerState.PendingTopUp
+		playerState.PendingTopUp = 0
 		if playerState.Bankroll == 0 {
 			playerState.IsParticipated = false
+			if isBankrollChanged && !te.isWaitingReBuy(playerState) {
+				eliminatedPlayers = append(eliminatedPlayers, playerState)
+			}
 		}
+		te.table.Meta.TimeBank.Replenish(playerState)
+
+		if isBankrollChanged {
+			te.emitTablePlayerStateEvent(playerState)
+		}
+	}
+
+	if te.handHistoryRecorder != nil {
+		te.handHistoryRecorder.RecordGameSettled(te.table, te.table.State.GameState)
 	}
 
-	te.emitEvent("SettleTableGameResult", "")
+	te.emitEvent(TableEventKind_TableGameSettled, "", gameSettlement)
+	te.eliminatePlayers(eliminatedPlayers)
+}
+
+func (te *tableEngine) eliminatePlayers(eliminatedPlayers []*TablePlayerState) {
+	if len(eliminatedPlayers) == 0 {
+		return
+	}
+
+	// player who had less chips at the beginning of the hand is eliminated first
+	sort.SliceStable(eliminatedPlayers, func(i, j int) bool {
+		return te.table.StartingStackSize(eliminatedPlayers[i].PlayerID) < te.table.StartingStackSize(eliminatedPlayers[j].PlayerID)
+	})
+
+	playerIDs := make([]string, 0, len(eliminatedPlayers))
+	for _, player := range eliminatedPlayers {
+		playerIDs = append(playerIDs, player.PlayerID)
+		if te.table.IsEntryTracked() {
+			te.playerEntry(player.PlayerID).IsEliminated = true
+		}
+	}
+	te.emitEvent(TableEventKind_PlayersEliminated, strings.Join(playerIDs, ","), playerIDs)
+	te.onTablePlayersEliminated(te.table, eliminatedPlayers)
+	te.updateStandings(eliminatedPlayers)
+}
+
+// removeEliminatedPlayers lets players without chips leave table, busted players stay seated in cash game or while rebuy is available.
+func (te *tableEngine) removeEliminatedPlayers() {
+	if te.table.Meta.Mode == CompetitionMode_Cash {
+		return
+	}
+
+	playerIDs := make([]string, 0)
+	lateEliminatedPlayers := make([]*TablePlayerState, 0)
+	for _, player := range te.table.State.PlayerStates {
+		if player.Bankroll > 0 || te.isWaitingReBuy(player) {
+			continue
+		}
+
+		// rebuy is no longer available for players who kept their seats
+		if entry := te.table.FindPlayerEntry(player.PlayerID); entry != nil && !entry.IsEliminated {
+			lateEliminatedPlayers = append(lateEliminatedPlayers, player)
+		}
+		playerIDs = append(playerIDs, player.PlayerID)
+	}
+
+	if len(playerIDs) == 0 {
+		return
+	}
+
+	te.eliminatePlayers(lateEliminatedPlayers)
+	te.batchRemovePlayers(playerIDs)
+	te.emitEvent(TableEventKind_PlayersLeft, strings.Join(playerIDs, ","), playerIDs)
 }
 
 func (te *tableEngine) continueGame() error {
@@ -345,17 +537,28 @@ func (te *tableEngine) continueGame() error {
 		playerState.GameStatistics.IsFold = false
 		playerState.GameStatistics.FoldRound = ""
 	}
+	te.removeEliminatedPlayers()
+	te.evictSittingOutPlayers()
+
+	// players are able to be moved to other tables between hands
+	te.emitTableStateEvent(TableEventKind_TableGameStandby)
 
 	return te.delay(te.options.Interval, func() error {
 		if te.table.State.Status == TableStateStatus_TableClosed {
 			return nil
 		}
 
+		if te.table.IsSitAndGoFinished() {
+			return te.CloseTable()
+		}
+
+		te.refreshBlindLevel()
+
 		if te.table.ShouldPause() {
 			te.table.State.Status = TableStateStatus_TablePausing
-			te.emitEvent("ContinueGame -> Pause", "")
+			te.emitEvent(TableEventKind_TablePaused, "", nil)
 		} else {
-			if te.table.State.Status == TableStateStatus_TableGameStandby && len(te.table.AlivePlayers()) >= te.table.Meta.TableMinPlayerCount {
+			if te.table.State.Status == TableStateStatus_TableGameStandby && len(te.table.ActivePlayers()) >= te.table.Meta.TableMinPlayerCount {
 				return te.TableGameOpen()
 			}
 		}
@@ -367,3 +570,49 @@ func (te *tableEngine) onGameClosed() error {
 	te.settleGame()
 	return te.continueGame()
 }
+
+func gamePlayerStackSize(gs *pokerface.GameState, gamePlayerIdx int) int64 {
+	player := gs.GetPlayer(gamePlayerIdx)
+	if player == nil {
+		return 0
+	}
+	return player.StackSize
+}
+
+func gamePlayerWager(gs *pokerface.GameState, gamePlayerIdx int) int64 {
+	player := gs.GetPlayer(gamePlayerIdx)
+	if player == nil {
+		return 0
+	}
+	return player.Wager
+}
+
+// newGamePlayerAction describes the move by the game state it produced, the game may have moved on to the next round since.
+func (te *tableEngine) newGamePlayerAction(gs *pokerface.GameState, gamePlayerIdx int, action string, chips int64) TablePlayerGameAction {
+	playerIdx := te.table.State.GamePlayerIndexes[gamePlayerIdx]
+	playerState := te.table.State.PlayerStates[playerIdx]
+
+	gameAction := TablePlayerGameAction{
+		CompetitionID: te.table.Meta.CompetitionID,
+		TableID:       te.table.ID,
+		GameID:        gs.GameID,
+		GameCount:     te.table.State.GameCount,
+		Round:         gs.Status.Round,
+		UpdateAt:      time.Now().Unix(),
+		PlayerID:      playerState.PlayerID,
+		Seat:          playerState.Seat,
+		Positions:     playerState.Positions,
+		Action:        action,
+		Chips:         chips,
+	}
+
+	if player := gs.GetPlayer(gamePlayerIdx); player != nil {
+		gameAction.Bankroll = player.Bankroll
+		gameAction.InitialStackSize = player.InitialStackSize
+		gameAction.StackSize = player.StackSize
+		gameAction.Pot = player.Pot
+		gameAction.Wager = player.Wager
+	}
+
+	return gameAction
+}
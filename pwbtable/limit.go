@@ -0,0 +1,208 @@
+package pwbtable
+
+import (
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/pokerface"
+)
+
+type TableFixedLimitSetting struct {
+	RaiseCap          int  `json:"raise_cap"`            // raises allowed after the first bet of a round, 3 by default
+	IsHeadsUpUncapped bool `json:"is_heads_up_uncapped"` // no cap once only two players are in hand
+}
+
+// TableFixedLimitState counts full bets and raises of current betting round, an incomplete all-in raise is not counted.
+type TableFixedLimitState struct {
+	GameID    string `json:"game_id"`
+	Round     string `json:"round"`
+	Raises    int    `json:"raises"`     // full bets and raises, big blind counts as the first bet
+	ChipLevel int64  `json:"chip_level"` // wager of the last full bet or raise
+}
+
+func (s TableFixedLimitSetting) Validate() error {
+	if s.RaiseCap < 0 {
+		return ErrTableInvalidCreateSetting
+	}
+	return nil
+}
+
+func (s TableFixedLimitSetting) MaxRaises() int {
+	if s.RaiseCap == 0 {
+		return 3
+	}
+	return s.RaiseCap
+}
+
+// BettingLimit returns betting limit of table, it is decided by rule set unless specified.
+func (m TableMeta) BettingLimit() string {
+	if m.Limit != "" {
+		return m.Limit
+	}
+	return ruleSetOf(m.Rule).BettingLimit()
+}
+
+func validateBettingLimit(limit string) error {
+	switch limit {
+	case BettingLimit_NoLimit, BettingLimit_PotLimit, BettingLimit_FixedLimit:
+		return nil
+	}
+	return ErrTableInvalidCreateSetting
+}
+
+// PotLimitChipLevel returns the pot-limit maximum to raise to: current wager plus the pot after calling.
+func PotLimitChipLevel(gs *pokerface.GameState, gamePlayerIdx int) int64 {
+	// player pot includes wager of current round
+	pot := int64(0)
+	for _, p := range gs.Players {
+		pot += p.Pot
+	}
+
+	toCall := gs.Status.CurrentWager - gs.Players[gamePlayerIdx].Wager
+	if toCall < 0 {
+		toCall = 0
+	}
+
+	return gs.Status.CurrentWager + pot + toCall
+}
+
+// FixedLimitBetSize returns bet size of round in fixed limit, small bet before the turn and big bet since the turn.
+func (t Table) FixedLimitBetSize(round string) int64 {
+	bs := t.State.BlindState
+	if round == GameRound_Turn || round == GameRound_River {
+		if bs.BigBet > 0 {
+			return bs.BigBet
+		}
+		return bs.BB * 2
+	}
+
+	if bs.SmallBet > 0 {
+		return bs.SmallBet
+	}
+	return bs.BB
+}
+
+// fixedLimitRaises returns full bets and raises of current round and wager of the last one.
+func (t Table) fixedLimitRaises(gs *pokerface.GameState) (int, int64) {
+	state := t.State.FixedLimit
+	if state.GameID == gs.GameID && state.Round == gs.Status.Round {
+		return state.Raises, state.ChipLevel
+	}
+
+	// nobody bets or raises in current round yet
+	if gs.Status.Round == GameRound_Preflop {
+		return 1, gs.Meta.Blind.BB
+	}
+	return 0, 0
+}
+
+// recordFixedLimitWager counts wager which player bets or raises to in fixed limit if it is a full bet or raise.
+func (t Table) recordFixedLimitWager(gs *pokerface.GameState, chipLevel int64) {
+	if t.Meta.BettingLimit() != BettingLimit_FixedLimit {
+		return
+	}
+
+	raises, _ := t.fixedLimitRaises(gs)
+	if chipLevel < t.FixedLimitChipLevel(gs) {
+		return
+	}
+
+	t.State.FixedLimit = TableFixedLimitState{
+		GameID:    gs.GameID,
+		Round:     gs.Status.Round,
+		Raises:    raises + 1,
+		ChipLevel: chipLevel,
+	}
+}
+
+// IsRaiseCapped returns whether no more raise is allowed in current round of fixed limit, big blind counts as the first bet.
+func (t Table) IsRaiseCapped(gs *pokerface.GameState) bool {
+	setting := t.Meta.FixedLimit
+	if setting.IsHeadsUpUncapped {
+		inHandCount := 0
+		for _, p := range gs.Players {
+			if !p.Fold {
+				inHandCount++
+			}
+		}
+
+		if inHandCount <= 2 {
+			return false
+		}
+	}
+
+	raises, _ := t.fixedLimitRaises(gs)
+	return raises-1 >= setting.MaxRaises()
+}
+
+// FixedLimitChipLevel returns the wager to bet or raise to in current round of fixed limit,
+// it is one bet size over the last full bet or raise so an incomplete all-in raise is completed.
+func (t Table) FixedLimitChipLevel(gs *pokerface.GameState) int64 {
+	_, chipLevel := t.fixedLimitRaises(gs)
+	return chipLevel + t.FixedLimitBetSize(gs.Status.Round)
+}
+
+// maxChipLevel returns the highest wager to raise to in current round, UnsetValue means no limit.
+func (t Table) maxChipLevel(gs *pokerface.GameState, gamePlayerIdx int) int64 {
+	switch t.Meta.BettingLimit() {
+	case BettingLimit_PotLimit:
+		return PotLimitChipLevel(gs, gamePlayerIdx)
+	case BettingLimit_FixedLimit:
+		if t.IsRaiseCapped(gs) {
+			return gs.Status.CurrentWager
+		}
+		return t.FixedLimitChipLevel(gs)
+	}
+	return UnsetValue
+}
+
+// validateChipLevel rejects wager which player raises to over the betting limit, fixed limit only accepts the fixed size unless player is all-in.
+func (te *tableEngine) validateChipLevel(gamePlayerIdx int, chipLevel int64, isAllin bool) error {
+	gs := te.game.GetGameState()
+	if gs.GetPlayer(gamePlayerIdx) == nil {
+		return nil
+	}
+
+	maxChipLevel := te.table.maxChipLevel(gs, gamePlayerIdx)
+	if maxChipLevel == UnsetValue || (isAllin && chipLevel <= gs.Status.CurrentWager) {
+		return nil
+	}
+
+	if te.table.Meta.BettingLimit() == BettingLimit_FixedLimit {
+		if maxChipLevel <= gs.Status.CurrentWager {
+			return ErrTableRaiseCapReached
+		}
+
+		if !isAllin && chipLevel != maxChipLevel {
+			return ErrTableInvalidWagerSize
+		}
+	}
+
+	if chipLevel > maxChipLevel {
+		return ErrTableWagerExceedsLimit
+	}
+	return nil
+}
+
+// applyBettingLimit removes raise and allin from allowed actions of player when they are over the limit.
+func (t Table) applyBettingLimit(gs *pokerface.GameState) {
+	if t.Meta.BettingLimit() == BettingLimit_NoLimit {
+		return
+	}
+
+	for _, player := range gs.Players {
+		if len(player.AllowedActions) == 0 {
+			continue
+		}
+
+		maxChipLevel := t.maxChipLevel(gs, player.Idx)
+		allinChipLevel := player.Wager + player.StackSize
+		player.AllowedActions = funk.FilterString(player.AllowedActions, func(action string) bool {
+			switch action {
+			case WagerAction_AllIn:
+				return allinChipLevel <= maxChipLevel || allinChipLevel <= gs.Status.CurrentWager
+			case WagerAction_Raise:
+				return maxChipLevel > gs.Status.CurrentWager
+			}
+			return true
+		})
+	}
+}
@@ -6,7 +6,8 @@ import (
 )
 
 var (
-	ErrManagerTableNotFound = errors.New("manager: table not found")
+	ErrManagerTableNotFound      = errors.New("manager: table not found")
+	ErrManagerTableStoreDisabled = errors.New("manager: table store is not enabled")
 )
 
 type Manager interface {
@@ -15,6 +16,7 @@ type Manager interface {
 	// Table Actions
 	GetTableEngine(tableID string) (TableEngine, error)
 	CreateTable(options *TableEngineOptions, callbacks *TableEngineCallbacks, setting TableSetting) (*Table, error)
+	RestoreTable(options *TableEngineOptions, callbacks *TableEngineCallbacks, tableID string) (*Table, error)
 	PauseTable(tableID string) error
 	CloseTable(tableID string) error
 	StartTableGame(tableID string) error
@@ -25,6 +27,10 @@ type Manager interface {
 	PlayerReserve(tableID string, joinPlayer JoinPlayer) error
 	PlayerJoin(tableID, playerID string) error
 	PlayerRedeemChips(tableID string, joinPlayer JoinPlayer) error
+	PlayerAddOn(tableID string, joinPlayer JoinPlayer) error
+	PlayerSitOut(tableID, playerID string) error
+	PlayerSitIn(tableID, playerID string, isWaitingBB bool) error
+	PlayerAutoMode(tableID, playerID string, isOn bool) error
 	PlayersLeave(tableID string, playerIDs []string) error
 
 	// Player Game Actions
@@ -37,16 +43,33 @@ type Manager interface {
 	PlayerCheck(tableID, playerID string) error
 	PlayerFold(tableID, playerID string) error
 	PlayerPass(tableID, playerID string) error
+	PlayerExtendTime(tableID, playerID string, duration int) error
 }
 
+type ManagerOpt func(*manager)
+
 type manager struct {
 	tableEngines sync.Map
+	tableStore   TableStore
 }
 
-func NewManager() Manager {
-	return &manager{
+func NewManager(opts ...ManagerOpt) Manager {
+	m := &manager{
 		tableEngines: sync.Map{},
 	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// WithManagerTableStore saves snapshots of all tables so that they can be restored by RestoreTable.
+func WithManagerTableStore(store TableStore) ManagerOpt {
+	return func(m *manager) {
+		m.tableStore = store
+	}
 }
 
 func (m *manager) Reset() {
@@ -67,6 +90,38 @@ func (m *manager) GetTableEngine(tableID string) (TableEngine, error) {
 }
 
 func (m *manager) CreateTable(options *TableEngineOptions, callbacks *TableEngineCallbacks, setting TableSetting) (*Table, error) {
+	tableEngine := m.newTableEngine(options, callbacks)
+	table, err := tableEngine.CreateTable(setting)
+	if err != nil {
+		return nil, err
+	}
+
+	m.tableEngines.Store(table.ID, tableEngine)
+	return table, nil
+}
+
+func (m *manager) RestoreTable(options *TableEngineOptions, callbacks *TableEngineCallbacks, tableID string) (*Table, error) {
+	if m.tableStore == nil {
+		return nil, ErrManagerTableStoreDisabled
+	}
+
+	table, err := m.tableStore.LoadTable(tableID)
+	if err != nil {
+		return nil, err
+	}
+
+	// restored game may request actions before RestoreTable returns
+	tableEngine := m.newTableEngine(options, callbacks)
+	m.tableEngines.Store(tableID, tableEngine)
+	if err := tableEngine.RestoreTable(table); err != nil {
+		m.tableEngines.Delete(tableID)
+		return nil, err
+	}
+
+	return tableEngine.GetTable(), nil
+}
+
+func (m *manager) newTableEngine(options *TableEngineOptions, callbacks *TableEngineCallbacks) TableEngine {
 	var engineOptions *TableEngineOptions
 	if options != nil {
 		engineOptions = options
@@ -82,21 +137,22 @@ func (m *manager) CreateTable(options *TableEngineOptions, callbacks *TableEngin
 		engineCallbacks = NewTableEngineCallbacks()
 	}
 
-	gameBackend := NewNativeGameBackend()
-	tableEngine := NewTableEngine(engineOptions, WithGameBackend(gameBackend))
+	engineOpts := []TableEngineOpt{WithGameBackend(NewNativeGameBackend())}
+	if m.tableStore != nil {
+		engineOpts = append(engineOpts, WithTableStore(m.tableStore))
+	}
+
+	tableEngine := NewTableEngine(engineOptions, engineOpts...)
 	tableEngine.OnTableUpdated(engineCallbacks.OnTableUpdated)
 	tableEngine.OnTableErrorUpdated(engineCallbacks.OnTableErrorUpdated)
 	tableEngine.OnTableStateUpdated(engineCallbacks.OnTableStateUpdated)
 	tableEngine.OnTablePlayerStateUpdated(engineCallbacks.OnTablePlayerStateUpdated)
 	tableEngine.OnTablePlayerReserved(engineCallbacks.OnTablePlayerReserved)
 	tableEngine.OnGamePlayerActionUpdated(engineCallbacks.OnGamePlayerActionUpdated)
-	table, err := tableEngine.CreateTable(setting)
-	if err != nil {
-		return nil, err
-	}
+	tableEngine.OnTableViewUpdated(engineCallbacks.OnTableViewUpdated)
+	tableEngine.OnTablePlayersEliminated(engineCallbacks.OnTablePlayersEliminated)
 
-	m.tableEngines.Store(table.ID, tableEngine)
-	return table, nil
+	return tableEngine
 }
 
 func (m *manager) PauseTable(tableID string) error {
@@ -177,6 +233,42 @@ func (m *manager) PlayerRedeemChips(tableID string, joinPlayer JoinPlayer) error
 	return tableEngine.PlayerRedeemChips(joinPlayer)
 }
 
+func (m *manager) PlayerAddOn(tableID string, joinPlayer JoinPlayer) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.PlayerAddOn(joinPlayer)
+}
+
+func (m *manager) PlayerSitOut(tableID, playerID string) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.PlayerSitOut(playerID)
+}
+
+func (m *manager) PlayerSitIn(tableID, playerID string, isWaitingBB bool) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.PlayerSitIn(playerID, isWaitingBB)
+}
+
+func (m *manager) PlayerAutoMode(tableID, playerID string, isOn bool) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.PlayerAutoMode(playerID, isOn)
+}
+
 func (m *manager) PlayersLeave(tableID string, playerIDs []string) error {
 	tableEngine, err := m.GetTableEngine(tableID)
 	if err != nil {
@@ -266,3 +358,12 @@ func (m *manager) PlayerPass(tableID, playerID string) error {
 
 	return tableEngine.PlayerPass(playerID)
 }
+
+func (m *manager) PlayerExtendTime(tableID, playerID string, duration int) error {
+	tableEngine, err := m.GetTableEngine(tableID)
+	if err != nil {
+		return ErrManagerTableNotFound
+	}
+
+	return tableEngine.PlayerExtendTime(playerID, duration)
+}
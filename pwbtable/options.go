@@ -7,6 +7,8 @@ type TableEngineCallbacks struct {
 	OnTablePlayerStateUpdated func(string, string, *TablePlayerState)
 	OnTablePlayerReserved     func(string, string, *TablePlayerState)
 	OnGamePlayerActionUpdated func(TablePlayerGameAction)
+	OnTableViewUpdated        func(playerID string, t *Table)
+	OnTablePlayersEliminated  func(t *Table, players []*TablePlayerState)
 }
 
 func NewTableEngineCallbacks() *TableEngineCallbacks {
@@ -17,15 +19,21 @@ func NewTableEngineCallbacks() *TableEngineCallbacks {
 		OnTablePlayerStateUpdated: func(string, string, *TablePlayerState) {},
 		OnTablePlayerReserved:     func(string, string, *TablePlayerState) {},
 		OnGamePlayerActionUpdated: func(TablePlayerGameAction) {},
+		OnTableViewUpdated:        func(string, *Table) {},
+		OnTablePlayersEliminated:  func(*Table, []*TablePlayerState) {},
 	}
 }
 
 type TableEngineOptions struct {
-	Interval int
+	Interval          int
+	EnablePlayerViews bool             // deliver redacted table of every player and spectator by OnTableViewUpdated
+	AutoModeStrategy  AutoModeStrategy // wager moves of players in auto mode, check or fold by default
 }
 
 func NewTableEngineOptions() *TableEngineOptions {
 	return &TableEngineOptions{
-		Interval: 0, // 0 second by default
+		Interval:          0, // 0 second by default
+		EnablePlayerViews: false,
+		AutoModeStrategy:  NewCheckOrFoldStrategy(),
 	}
 }
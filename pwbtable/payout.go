@@ -0,0 +1,232 @@
+package pwbtable
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+var (
+	ErrPayoutInvalidSetting = errors.New("payout: invalid setting")
+)
+
+type PayoutStructureType string
+
+const (
+	PayoutStructureType_Ladder  PayoutStructureType = "ladder"
+	PayoutStructureType_Formula PayoutStructureType = "formula"
+)
+
+type PayoutSetting struct {
+	BuyIn        int64           `json:"buy_in"` // goes to prize pool
+	Fee          int64           `json:"fee"`    // goes to house
+	EntrantCount int             `json:"entrant_count"`
+	RebuyCount   int             `json:"rebuy_count"`
+	RebuyPrice   int64           `json:"rebuy_price"`
+	AddOnCount   int             `json:"add_on_count"`
+	AddOnPrice   int64           `json:"add_on_price"`
+	MinChipUnit  int64           `json:"min_chip_unit"`
+	Structure    PayoutStructure `json:"structure"`
+}
+
+type PayoutStructure struct {
+	Type        PayoutStructureType `json:"type"`
+	Percentages []float64           `json:"percentages"` // ladder: percentage of prize pool by place, first place first
+	PaidRatio   float64             `json:"paid_ratio"`  // formula: ratio of entrants who are paid
+	Decay       float64             `json:"decay"`       // formula: prize of next place is the prize of previous place multiplied by decay
+}
+
+type PayoutBustedPlayer struct {
+	PlayerID      string `json:"player_id"`
+	StartingStack int64  `json:"starting_stack"` // stack at the beginning of the hand the player is busted
+}
+
+type PayoutPlace struct {
+	PlayerID string `json:"player_id"`
+	Place    int    `json:"place"`
+}
+
+func (ps PayoutSetting) Validate() error {
+	if ps.BuyIn < 0 || ps.Fee < 0 || ps.EntrantCount < 0 || ps.RebuyCount < 0 || ps.RebuyPrice < 0 || ps.AddOnCount < 0 || ps.AddOnPrice < 0 || ps.MinChipUnit < 0 {
+		return ErrPayoutInvalidSetting
+	}
+
+	switch ps.Structure.Type {
+	case PayoutStructureType_Ladder:
+		total := 0.0
+		for _, percentage := range ps.Structure.Percentages {
+			if percentage < 0 {
+				return ErrPayoutInvalidSetting
+			}
+			total += percentage
+		}
+
+		if total > 100 {
+			return ErrPayoutInvalidSetting
+		}
+	case PayoutStructureType_Formula:
+		if ps.Structure.PaidRatio <= 0 || ps.Structure.PaidRatio > 1 || ps.Structure.Decay <= 0 || ps.Structure.Decay > 1 {
+			return ErrPayoutInvalidSetting
+		}
+	default:
+		return ErrPayoutInvalidSetting
+	}
+
+	return nil
+}
+
+func (ps PayoutSetting) PrizePool() int64 {
+	return ps.BuyIn*int64(ps.EntrantCount) + ps.RebuyPrice*int64(ps.RebuyCount) + ps.AddOnPrice*int64(ps.AddOnCount)
+}
+
+func (ps PayoutSetting) TotalFee() int64 {
+	return ps.Fee * int64(ps.EntrantCount)
+}
+
+// Percentages returns percentage of prize pool by place, places are never more than entrants.
+func (ps PayoutSetting) Percentages() []float64 {
+	percentages := make([]float64, 0)
+
+	switch ps.Structure.Type {
+	case PayoutStructureType_Ladder:
+		percentages = append(percentages, ps.Structure.Percentages...)
+	case PayoutStructureType_Formula:
+		paidCount := int(math.Floor(float64(ps.EntrantCount) * ps.Structure.PaidRatio))
+		if paidCount < 1 {
+			paidCount = 1
+		}
+
+		total := 0.0
+		weights := make([]float64, paidCount)
+		for i := range weights {
+			weights[i] = math.Pow(ps.Structure.Decay, float64(i))
+			total += weights[i]
+		}
+
+		for _, weight := range weights {
+			percentages = append(percentages, weight/total*100)
+		}
+	}
+
+	// percentages of places which are never reached go to paid places in proportion
+	if len(percentages) > ps.EntrantCount {
+		total := sumPercentages(percentages)
+		percentages = percentages[:ps.EntrantCount]
+
+		paidTotal := sumPercentages(percentages)
+		for idx := range percentages {
+			if paidTotal > 0 {
+				percentages[idx] *= total / paidTotal
+			}
+		}
+
+		if paidTotal == 0 && len(percentages) > 0 {
+			percentages[0] = total
+		}
+	}
+	return percentages
+}
+
+func sumPercentages(percentages []float64) float64 {
+	total := 0.0
+	for _, percentage := range percentages {
+		total += percentage
+	}
+	return total
+}
+
+// Prizes returns prize by place, amounts are rounded down to chip unit and the rest goes to the first place in whole chip units.
+func (ps PayoutSetting) Prizes() ([]int64, error) {
+	if err := ps.Validate(); err != nil {
+		return nil, err
+	}
+
+	prizePool := ps.PrizePool()
+	percentages := ps.Percentages()
+	prizes := make([]int64, len(percentages))
+	if len(prizes) == 0 {
+		return prizes, nil
+	}
+
+	total := int64(0)
+	totalPercentage := 0.0
+	for idx, percentage := range percentages {
+		prizes[idx] = roundDownChips(int64(float64(prizePool)*percentage/100), ps.MinChipUnit)
+		total += prizes[idx]
+		totalPercentage += percentage
+	}
+
+	// formula structure pays whole prize pool
+	paid := int64(math.Round(float64(prizePool) * totalPercentage / 100))
+	if paid > prizePool {
+		paid = prizePool
+	}
+	if total < paid {
+		prizes[0] += roundDownChips(paid-total, ps.MinChipUnit)
+	}
+
+	return prizes, nil
+}
+
+// RankBustedPlayers gives places to players who are busted in the same hand, the bigger starting stack finishes higher
+// and players with the same starting stack share the best place of them. worstPlace is the place of the first one busted.
+func RankBustedPlayers(players []PayoutBustedPlayer, worstPlace int) []*PayoutPlace {
+	sortedPlayers := append(make([]PayoutBustedPlayer, 0, len(players)), players...)
+	sort.SliceStable(sortedPlayers, func(i, j int) bool {
+		return sortedPlayers[i].StartingStack > sortedPlayers[j].StartingStack
+	})
+
+	places := make([]*PayoutPlace, 0, len(sortedPlayers))
+	bestPlace := worstPlace - len(sortedPlayers) + 1
+	for idx, player := range sortedPlayers {
+		place := bestPlace + idx
+		if idx > 0 && player.StartingStack == sortedPlayers[idx-1].StartingStack {
+			place = places[idx-1].Place
+		}
+
+		places = append(places, &PayoutPlace{
+			PlayerID: player.PlayerID,
+			Place:    place,
+		})
+	}
+
+	return places
+}
+
+// SplitPrizes gives prize to every place, players sharing the same place split prizes of the places they occupy.
+func SplitPrizes(prizes []int64, places []*PayoutPlace, minChipUnit int64) map[string]int64 {
+	groups := make(map[int][]string)
+	for _, place := range places {
+		groups[place.Place] = append(groups[place.Place], place.PlayerID)
+	}
+
+	result := make(map[string]int64)
+	for place, playerIDs := range groups {
+		total := int64(0)
+		for p := place; p < place+len(playerIDs); p++ {
+			if p >= 1 && p <= len(prizes) {
+				total += prizes[p-1]
+			}
+		}
+
+		share := roundDownChips(total/int64(len(playerIDs)), minChipUnit)
+		rest := total - share*int64(len(playerIDs))
+		for idx, playerID := range playerIDs {
+			result[playerID] = share
+
+			// odd chips go to the first player
+			if idx == 0 {
+				result[playerID] += rest
+			}
+		}
+	}
+
+	return result
+}
+
+func roundDownChips(chips int64, minChipUnit int64) int64 {
+	if minChipUnit <= 0 {
+		return chips
+	}
+	return chips - chips%minChipUnit
+}
@@ -1,10 +1,5 @@
 package pwbtable
 
-import (
-	"math/rand"
-	"time"
-)
-
 func NewDefaultSeatMap(seatCount int) []int {
 	seatMap := make([]int, seatCount)
 	for seatIdx := 0; seatIdx < seatCount; seatIdx++ {
@@ -13,7 +8,12 @@ func NewDefaultSeatMap(seatCount int) []int {
 	return seatMap
 }
 
+// RandomSeats picks empty seats with the default rand source.
 func RandomSeats(seatMap []int, count int) ([]int, error) {
+	return RandomSeatsWithSource(defaultRandSource, seatMap, count)
+}
+
+func RandomSeatsWithSource(rs RandSource, seatMap []int, count int) ([]int, error) {
 	emptySeats := make([]int, 0)
 	for seatIdx, playerIdx := range seatMap {
 		if playerIdx == UnsetValue {
@@ -25,8 +25,7 @@ func RandomSeats(seatMap []int, count int) ([]int, error) {
 		return nil, ErrTableNoEmptySeats
 	}
 
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(emptySeats), func(i, j int) {
+	rs.Shuffle(len(emptySeats), func(i, j int) {
 		emptySeats[i], emptySeats[j] = emptySeats[j], emptySeats[i]
 	})
 
@@ -34,7 +33,7 @@ func RandomSeats(seatMap []int, count int) ([]int, error) {
 }
 
 func IsBetweenDealerBB(seatIdx, currDealerTableSeatIdx, currBBTableSeatIdx, maxPlayerCount int, rule string) bool {
-	if rule == CompetitionRule_ShortDeck {
+	if !ruleSetOf(rule).IsBetweenDealerBBWaiting() {
 		return false
 	}
 
@@ -49,10 +48,15 @@ func IsBetweenDealerBB(seatIdx, currDealerTableSeatIdx, currBBTableSeatIdx, maxP
 	return seatIdx < currBBTableSeatIdx && seatIdx > currDealerTableSeatIdx
 }
 
+// FindDealerPlayerIndex picks the first dealer with the default rand source.
 func FindDealerPlayerIndex(gameCount, prevDealerSeatIdx, minPlayingCount, maxSeatCount int, players []*TablePlayerState, seatMap []int) int {
+	return FindDealerPlayerIndexWithSource(defaultRandSource, gameCount, prevDealerSeatIdx, minPlayingCount, maxSeatCount, players, seatMap)
+}
+
+func FindDealerPlayerIndexWithSource(rs RandSource, gameCount, prevDealerSeatIdx, minPlayingCount, maxSeatCount int, players []*TablePlayerState, seatMap []int) int {
 	newDealerIdx := UnsetValue
 	if gameCount == 0 {
-		newDealerIdx = rand.Intn(len(players))
+		newDealerIdx = rs.Intn(len(players))
 
 		if !players[newDealerIdx].IsParticipated {
 			for playerIdx := 0; playerIdx < len(players); playerIdx++ {
@@ -110,7 +114,7 @@ func FindGamePlayerIndexes(dealerSeatIdx int, seatMap []int, players []*TablePla
 
 func GetPlayerPositionMap(rule string, players []*TablePlayerState, gamePlayerIndexes []int) map[int][]string {
 	playerPositionMap := make(map[int][]string)
-	positions := newPositions(len(gamePlayerIndexes))
+	positions := ruleSetOf(rule).Positions(len(gamePlayerIndexes))
 	for gamePlayerIdx, playerIdx := range gamePlayerIndexes {
 		playerPositionMap[playerIdx] = positions[gamePlayerIdx]
 	}
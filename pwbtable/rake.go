@@ -0,0 +1,129 @@
+package pwbtable
+
+import (
+	"github.com/weedbox/pokerface/settlement"
+)
+
+type TableRakeSetting struct {
+	Percentage     float64        `json:"percentage"` // 0 disables rake
+	Caps           []TableRakeCap `json:"caps"`
+	IsNoFlopNoDrop bool           `json:"is_no_flop_no_drop"`
+}
+
+// TableRakeCap limits rake of a hand which is dealt to at least MinPlayerCount players.
+type TableRakeCap struct {
+	MinPlayerCount int   `json:"min_player_count"`
+	Cap            int64 `json:"cap"`
+}
+
+type TableRakeState struct {
+	Total    int64   `json:"total"`     // accumulated rake of the table
+	PotRakes []int64 `json:"pot_rakes"` // rake of each pot in the latest hand
+}
+
+// TableGameSettlement is the payload of settled event, winners' chips are after rake.
+type TableGameSettlement struct {
+	*settlement.Result
+	PotRakes  []int64         `json:"pot_rakes"`
+	Rake      int64           `json:"rake"`
+	SplitPots []TableSplitPot `json:"split_pots,omitempty"` // halves of pots in hi/lo game
+}
+
+func (s TableRakeSetting) IsEnabled() bool {
+	return s.Percentage > 0
+}
+
+func (s TableRakeSetting) Validate() error {
+	if s.Percentage < 0 || s.Percentage > 100 {
+		return ErrTableInvalidCreateSetting
+	}
+
+	for _, rc := range s.Caps {
+		if rc.MinPlayerCount < 0 || rc.Cap < 0 {
+			return ErrTableInvalidCreateSetting
+		}
+	}
+
+	return nil
+}
+
+// Cap returns rake cap of a hand for player count, 0 means no cap.
+func (s TableRakeSetting) Cap(playerCount int) int64 {
+	rakeCap := int64(0)
+	minPlayerCount := UnsetValue
+	for _, rc := range s.Caps {
+		if playerCount >= rc.MinPlayerCount && rc.MinPlayerCount > minPlayerCount {
+			rakeCap = rc.Cap
+			minPlayerCount = rc.MinPlayerCount
+		}
+	}
+	return rakeCap
+}
+
+// ApplyRake takes rake from pots in order and deducts it from winners proportionally, it returns rake of each pot.
+func (s TableRakeSetting) ApplyRake(result *settlement.Result, isFlopDealt bool, minChipUnit int64) []int64 {
+	potRakes := make([]int64, len(result.Pots))
+	if !s.IsEnabled() || (s.IsNoFlopNoDrop && !isFlopDealt) {
+		return potRakes
+	}
+
+	rakeCap := s.Cap(len(result.Players))
+	remainingCap := rakeCap
+	deductions := make(map[int]int64)
+	for potIdx, pot := range result.Pots {
+		rake := roundDownChips(int64(float64(pot.Total)*s.Percentage/100), minChipUnit)
+		if rakeCap > 0 && rake > remainingCap {
+			rake = remainingCap
+		}
+
+		totalWithdraw := int64(0)
+		for _, winner := range pot.Winners {
+			totalWithdraw += winner.Withdraw
+		}
+
+		if rake <= 0 || totalWithdraw <= 0 {
+			continue
+		}
+		remainingCap -= rake
+		potRakes[potIdx] = rake
+
+		// split pot shares rake by withdraw, odd chips are taken from the first winner
+		deducted := int64(0)
+		for _, winner := range pot.Winners {
+			share := rake * winner.Withdraw / totalWithdraw
+			winner.Withdraw -= share
+			deductions[winner.Idx] += share
+			deducted += share
+		}
+		pot.Winners[0].Withdraw -= rake - deducted
+		deductions[pot.Winners[0].Idx] += rake - deducted
+	}
+
+	for _, player := range result.Players {
+		player.Final -= deductions[player.Idx]
+		player.Changed -= deductions[player.Idx]
+	}
+
+	return potRakes
+}
+
+// settleRake takes rake from game result before chips are settled to bankrolls.
+func (te *tableEngine) settleRake() TableGameSettlement {
+	gs := te.table.State.GameState
+	potRakes := te.table.Meta.Rake.ApplyRake(gs.Result, len(gs.Status.Board) >= 3, te.table.Meta.MinChipUnit)
+
+	rake := int64(0)
+	for _, potRake := range potRakes {
+		rake += potRake
+	}
+
+	te.table.State.Rake.PotRakes = potRakes
+	te.table.State.Rake.Total += rake
+
+	return TableGameSettlement{
+		Result:    gs.Result,
+		PotRakes:  potRakes,
+		Rake:      rake,
+		SplitPots: te.table.State.SplitPots,
+	}
+}
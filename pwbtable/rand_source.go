@@ -0,0 +1,78 @@
+package pwbtable
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+	"sync"
+)
+
+// RandSource is the randomness used by table for seating, first dealer and shuffling.
+type RandSource interface {
+	Intn(n int) int
+	Int63n(n int64) int64
+	Float64() float64
+	Shuffle(n int, swap func(i, j int))
+}
+
+// defaultRandSource is used by functions which are not given a source.
+var defaultRandSource = NewCryptoRandSource()
+
+type lockedRandSource struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+// NewCryptoRandSource reads from crypto/rand, it is the default source of table engine.
+func NewCryptoRandSource() RandSource {
+	return &lockedRandSource{
+		r: rand.New(cryptoSource{}),
+	}
+}
+
+// NewSeededRandSource always generates the same sequence for the same seed, for tests and simulations.
+func NewSeededRandSource(seed int64) RandSource {
+	return &lockedRandSource{
+		r: rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (s *lockedRandSource) Intn(n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Intn(n)
+}
+
+func (s *lockedRandSource) Int63n(n int64) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Int63n(n)
+}
+
+func (s *lockedRandSource) Float64() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Float64()
+}
+
+func (s *lockedRandSource) Shuffle(n int, swap func(i, j int)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.r.Shuffle(n, swap)
+}
+
+type cryptoSource struct{}
+
+func (cryptoSource) Seed(int64) {}
+
+func (s cryptoSource) Int63() int64 {
+	return int64(s.Uint64() & (1<<63 - 1))
+}
+
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return binary.BigEndian.Uint64(b[:])
+}
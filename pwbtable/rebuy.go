@@ -0,0 +1,226 @@
+package pwbtable
+
+import (
+	"time"
+)
+
+// TableReBuySetting lets players buy chips again at their seat, busted players keep their seats while rebuy is available.
+type TableReBuySetting struct {
+	MaxCount     int              `json:"max_count"` // 0 disables rebuy
+	Period       TableBuyInPeriod `json:"period"`
+	MaxStackSize int64            `json:"max_stack_size"` // 0 means only busted players are able to rebuy
+}
+
+// TableAddOnSetting lets players buy extra chips during breaks.
+type TableAddOnSetting struct {
+	MaxCount int `json:"max_count"` // 0 disables add-on
+	Breaks   int `json:"breaks"`    // add-on is offered at the first n breaks, 0 means every break
+}
+
+// TableReEntrySetting lets eliminated players buy in again as a fresh entry.
+type TableReEntrySetting struct {
+	MaxCount int              `json:"max_count"` // 0 disables re-entry
+	Period   TableBuyInPeriod `json:"period"`
+}
+
+type TableBuyInPeriod struct {
+	Levels   int `json:"levels"`   // number of blind structure levels (breaks included), 0 means no limit
+	Duration int `json:"duration"` // seconds since table started, 0 means no limit
+}
+
+type TablePlayerEntry struct {
+	PlayerID     string `json:"player_id"`
+	ReBuyCount   int    `json:"rebuy_count"`
+	AddOnCount   int    `json:"add_on_count"`
+	ReEntryCount int    `json:"re_entry_count"`
+	IsEliminated bool   `json:"is_eliminated"`
+}
+
+func (s TableReBuySetting) Validate() error {
+	if s.MaxCount < 0 || s.MaxStackSize < 0 {
+		return ErrTableInvalidCreateSetting
+	}
+	return s.Period.Validate()
+}
+
+func (s TableAddOnSetting) Validate() error {
+	if s.MaxCount < 0 || s.Breaks < 0 {
+		return ErrTableInvalidCreateSetting
+	}
+	return nil
+}
+
+func (s TableReEntrySetting) Validate() error {
+	if s.MaxCount < 0 {
+		return ErrTableInvalidCreateSetting
+	}
+	return s.Period.Validate()
+}
+
+func (p TableBuyInPeriod) Validate() error {
+	if p.Levels < 0 || p.Duration < 0 {
+		return ErrTableInvalidCreateSetting
+	}
+	return nil
+}
+
+func (p TableBuyInPeriod) IsOpen(t Table, now int64) bool {
+	if p.Levels > 0 && t.Meta.BlindStructure.IsEnabled() && t.State.BlindState.LevelIndex >= p.Levels {
+		return false
+	}
+
+	if p.Duration > 0 && t.State.StartAt != UnsetValue && now >= t.State.StartAt+int64(p.Duration) {
+		return false
+	}
+
+	return true
+}
+
+func (t Table) FindPlayerEntry(playerID string) *TablePlayerEntry {
+	for _, entry := range t.State.PlayerEntries {
+		if entry.PlayerID == playerID {
+			return entry
+		}
+	}
+	return nil
+}
+
+// IsEntryTracked tells whether rebuy, add-on and re-entry counts are tracked, cash game has no entry limits.
+func (t Table) IsEntryTracked() bool {
+	return t.Meta.Mode != CompetitionMode_Cash
+}
+
+// entryOf returns counts of player without adding an entry, so validation leaves table state untouched.
+func (t Table) entryOf(playerID string) TablePlayerEntry {
+	if entry := t.FindPlayerEntry(playerID); entry != nil {
+		return *entry
+	}
+	return TablePlayerEntry{PlayerID: playerID}
+}
+
+func (te *tableEngine) playerEntry(playerID string) *TablePlayerEntry {
+	entry := te.table.FindPlayerEntry(playerID)
+	if entry == nil {
+		entry = &TablePlayerEntry{PlayerID: playerID}
+		te.table.State.PlayerEntries = append(te.table.State.PlayerEntries, entry)
+	}
+	return entry
+}
+
+func (te *tableEngine) validateReBuy(playerState *TablePlayerState) error {
+	if !te.table.IsEntryTracked() {
+		return nil
+	}
+
+	setting := te.table.Meta.ReBuy
+	if setting.MaxCount == 0 {
+		return ErrTableReBuyNotAllowed
+	}
+
+	if te.table.entryOf(playerState.PlayerID).ReBuyCount >= setting.MaxCount {
+		return ErrTableBuyInLimitReached
+	}
+
+	if !setting.Period.IsOpen(*te.table, time.Now().Unix()) {
+		return ErrTableBuyInPeriodEnded
+	}
+
+	if playerState.Bankroll > setting.MaxStackSize {
+		return ErrTablePlayerStackTooLarge
+	}
+
+	// chips are settled by game result, so they can't be added during the hand
+	if te.isPlayerInHand(playerState) {
+		return ErrTableReBuyNotAllowed
+	}
+
+	return nil
+}
+
+func (te *tableEngine) validateAddOn(playerState *TablePlayerState) error {
+	setting := te.table.Meta.AddOn
+	if setting.MaxCount == 0 || !te.table.IsEntryTracked() {
+		return ErrTableAddOnNotAllowed
+	}
+
+	if te.table.entryOf(playerState.PlayerID).AddOnCount >= setting.MaxCount {
+		return ErrTableBuyInLimitReached
+	}
+
+	if !te.table.State.BlindState.IsBreaking() {
+		return ErrTableBuyInPeriodEnded
+	}
+
+	if setting.Breaks > 0 {
+		breaks := 0
+		for _, level := range te.table.Meta.BlindStructure.Levels[:te.table.State.BlindState.LevelIndex+1] {
+			if level.IsBreak {
+				breaks++
+			}
+		}
+
+		if breaks > setting.Breaks {
+			return ErrTableBuyInPeriodEnded
+		}
+	}
+
+	return nil
+}
+
+func (te *tableEngine) validateReEntry(playerID string) error {
+	setting := te.table.Meta.ReEntry
+	if setting.MaxCount == 0 {
+		return ErrTableReEntryNotAllowed
+	}
+
+	if te.table.entryOf(playerID).ReEntryCount >= setting.MaxCount {
+		return ErrTableBuyInLimitReached
+	}
+
+	if !setting.Period.IsOpen(*te.table, time.Now().Unix()) {
+		return ErrTableBuyInPeriodEnded
+	}
+
+	return nil
+}
+
+// carryPlayerEntry keeps counts which player made at another table of the competition.
+func (te *tableEngine) carryPlayerEntry(joinPlayer JoinPlayer) {
+	if joinPlayer.Entry == nil || !te.table.IsEntryTracked() {
+		return
+	}
+
+	entry := *joinPlayer.Entry
+	entry.PlayerID = joinPlayer.PlayerID
+	*te.playerEntry(joinPlayer.PlayerID) = entry
+}
+
+// isWaitingReBuy tells whether busted player keeps the seat for rebuy instead of being eliminated.
+func (te *tableEngine) isWaitingReBuy(playerState *TablePlayerState) bool {
+	if playerState.Bankroll > 0 || !te.table.IsEntryTracked() {
+		return false
+	}
+	return te.validateReBuy(playerState) == nil
+}
+
+func (te *tableEngine) PlayerAddOn(joinPlayer JoinPlayer) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	playerIdx := te.table.FindPlayerIdx(joinPlayer.PlayerID)
+	if playerIdx == UnsetValue {
+		return ErrTablePlayerNotFound
+	}
+
+	playerState := te.table.State.PlayerStates[playerIdx]
+	if err := te.validateAddOn(playerState); err != nil {
+		return err
+	}
+
+	te.playerEntry(playerState.PlayerID).AddOnCount++
+	playerState.Bankroll += joinPlayer.RedeemChips
+	te.emitTablePlayerStateEvent(playerState)
+
+	te.emitEvent(TableEventKind_PlayerAddedOn, joinPlayer.PlayerID, joinPlayer)
+	return nil
+}
@@ -0,0 +1,231 @@
+package pwbtable
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/weedbox/pokerface"
+)
+
+const (
+	// Replay Step Action
+	ReplayAction_ReadyForAll = "ready_for_all"
+	ReplayAction_PayAnte     = "pay_ante"
+	ReplayAction_PayBlinds   = "pay_blinds"
+	ReplayAction_Next        = "next"
+	ReplayAction_Pay         = "pay"
+	ReplayAction_Fold        = "fold"
+	ReplayAction_Check       = "check"
+	ReplayAction_Call        = "call"
+	ReplayAction_Allin       = "allin"
+	ReplayAction_Bet         = "bet"
+	ReplayAction_Raise       = "raise"
+	ReplayAction_Pass        = "pass"
+)
+
+type HandRecord struct {
+	GameID           string                 `json:"game_id"`
+	Options          *pokerface.GameOptions `json:"options"`
+	InitialStateHash string                 `json:"initial_state_hash"`
+	Steps            []*HandRecordStep      `json:"steps"`
+}
+
+type HandRecordStep struct {
+	Action        string `json:"action"`
+	GamePlayerIdx int    `json:"game_player_idx"`
+	Chips         int64  `json:"chips"`
+	StateHash     string `json:"state_hash"`
+}
+
+// RecordingGameBackend records every successful call of the wrapped backend so hands can be replayed.
+type RecordingGameBackend struct {
+	backend  GameBackend
+	mu       sync.RWMutex
+	maxHands int
+	gameIDs  []string
+	records  map[string]*HandRecord
+}
+
+// NewRecordingGameBackend keeps records of the latest maxHands hands, 0 means unlimited.
+func NewRecordingGameBackend(backend GameBackend, maxHands int) *RecordingGameBackend {
+	return &RecordingGameBackend{
+		backend:  backend,
+		maxHands: maxHands,
+		gameIDs:  make([]string, 0),
+		records:  make(map[string]*HandRecord),
+	}
+}
+
+func (rgb *RecordingGameBackend) HandRecord(gameID string) (*HandRecord, error) {
+	rgb.mu.RLock()
+	defer rgb.mu.RUnlock()
+
+	record, exist := rgb.records[gameID]
+	if !exist {
+		return nil, ErrReplayHandRecordNotFound
+	}
+
+	return record.Clone()
+}
+
+func (rgb *RecordingGameBackend) DeleteHandRecord(gameID string) {
+	rgb.mu.Lock()
+	defer rgb.mu.Unlock()
+
+	if _, exist := rgb.records[gameID]; !exist {
+		return
+	}
+
+	delete(rgb.records, gameID)
+	for i, id := range rgb.gameIDs {
+		if id == gameID {
+			rgb.gameIDs = append(rgb.gameIDs[:i], rgb.gameIDs[i+1:]...)
+			break
+		}
+	}
+}
+
+func (rgb *RecordingGameBackend) CreateGame(opts *pokerface.GameOptions) (*pokerface.GameState, error) {
+	// options have to be kept before game is created, including the deck order
+	recordOpts, err := cloneGameOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	gs, err := rgb.backend.CreateGame(opts)
+	if err != nil {
+		return gs, err
+	}
+
+	rgb.mu.Lock()
+	defer rgb.mu.Unlock()
+	rgb.records[gs.GameID] = &HandRecord{
+		GameID:           gs.GameID,
+		Options:          recordOpts,
+		InitialStateHash: GameStateHash(gs),
+		Steps:            make([]*HandRecordStep, 0),
+	}
+	rgb.gameIDs = append(rgb.gameIDs, gs.GameID)
+	if rgb.maxHands > 0 && len(rgb.gameIDs) > rgb.maxHands {
+		delete(rgb.records, rgb.gameIDs[0])
+		rgb.gameIDs = rgb.gameIDs[1:]
+	}
+
+	return gs, nil
+}
+
+func (rgb *RecordingGameBackend) ReadyForAll(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return rgb.record(gs, ReplayAction_ReadyForAll, 0, rgb.backend.ReadyForAll)
+}
+
+func (rgb *RecordingGameBackend) PayAnte(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return rgb.record(gs, ReplayAction_PayAnte, 0, rgb.backend.PayAnte)
+}
+
+func (rgb *RecordingGameBackend) PayBlinds(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return rgb.record(gs, ReplayAction_PayBlinds, 0, rgb.backend.PayBlinds)
+}
+
+func (rgb *RecordingGameBackend) Next(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return rgb.record(gs, ReplayAction_Next, 0, rgb.backend.Next)
+}
+
+func (rgb *RecordingGameBackend) Pay(gs *pokerface.GameState, chips int64) (*pokerface.GameState, error) {
+	return rgb.record(gs, ReplayAction_Pay, chips, func(gs *pokerface.GameState) (*pokerface.GameState, error) {
+		return rgb.backend.Pay(gs, chips)
+	})
+}
+
+func (rgb *RecordingGameBackend) Fold(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return rgb.record(gs, ReplayAction_Fold, 0, rgb.backend.Fold)
+}
+
+func (rgb *RecordingGameBackend) Check(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return rgb.record(gs, ReplayAction_Check, 0, rgb.backend.Check)
+}
+
+func (rgb *RecordingGameBackend) Call(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return rgb.record(gs, ReplayAction_Call, 0, rgb.backend.Call)
+}
+
+func (rgb *RecordingGameBackend) Allin(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return rgb.record(gs, ReplayAction_Allin, 0, rgb.backend.Allin)
+}
+
+func (rgb *RecordingGameBackend) Bet(gs *pokerface.GameState, chips int64) (*pokerface.GameState, error) {
+	return rgb.record(gs, ReplayAction_Bet, chips, func(gs *pokerface.GameState) (*pokerface.GameState, error) {
+		return rgb.backend.Bet(gs, chips)
+	})
+}
+
+func (rgb *RecordingGameBackend) Raise(gs *pokerface.GameState, chipLevel int64) (*pokerface.GameState, error) {
+	return rgb.record(gs, ReplayAction_Raise, chipLevel, func(gs *pokerface.GameState) (*pokerface.GameState, error) {
+		return rgb.backend.Raise(gs, chipLevel)
+	})
+}
+
+func (rgb *RecordingGameBackend) Pass(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return rgb.record(gs, ReplayAction_Pass, 0, rgb.backend.Pass)
+}
+
+func (rgb *RecordingGameBackend) record(gs *pokerface.GameState, action string, chips int64, fn func(*pokerface.GameState) (*pokerface.GameState, error)) (*pokerface.GameState, error) {
+	currentPlayer := gs.Status.CurrentPlayer
+
+	newState, err := fn(gs)
+	if err != nil {
+		return newState, err
+	}
+
+	rgb.mu.Lock()
+	defer rgb.mu.Unlock()
+
+	record, exist := rgb.records[gs.GameID]
+	if !exist {
+		return newState, nil
+	}
+
+	record.Steps = append(record.Steps, &HandRecordStep{
+		Action:        action,
+		GamePlayerIdx: currentPlayer,
+		Chips:         chips,
+		StateHash:     GameStateHash(newState),
+	})
+
+	return newState, nil
+}
+
+func (hr HandRecord) Clone() (*HandRecord, error) {
+	encoded, err := json.Marshal(hr)
+	if err != nil {
+		return nil, err
+	}
+
+	var cloneRecord HandRecord
+	if err := json.Unmarshal(encoded, &cloneRecord); err != nil {
+		return nil, err
+	}
+
+	return &cloneRecord, nil
+}
+
+func (hr HandRecord) GetJSON() (string, error) {
+	encoded, err := json.Marshal(hr)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+func cloneGameOptions(opts *pokerface.GameOptions) (*pokerface.GameOptions, error) {
+	encoded, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var cloneOpts pokerface.GameOptions
+	if err := json.Unmarshal(encoded, &cloneOpts); err != nil {
+		return nil, err
+	}
+
+	return &cloneOpts, nil
+}
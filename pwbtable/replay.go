@@ -0,0 +1,163 @@
+package pwbtable
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/weedbox/pokerface"
+)
+
+var (
+	ErrReplayHandRecordNotFound = errors.New("replay: hand record not found")
+	ErrReplayInvalidHandRecord  = errors.New("replay: invalid hand record")
+	ErrReplayUnknownAction      = errors.New("replay: unknown action")
+)
+
+type ReplayResult struct {
+	GameID        string               `json:"game_id"`
+	ReplayedSteps int                  `json:"replayed_steps"`
+	Divergence    *ReplayDivergence    `json:"divergence,omitempty"`
+	FinalState    *pokerface.GameState `json:"final_state"`
+}
+
+// ReplayDivergence describes the first step whose state is different from the recorded one.
+// Step is UnsetValue when the initial state is already different.
+type ReplayDivergence struct {
+	Step         int    `json:"step"`
+	Action       string `json:"action"`
+	ExpectedHash string `json:"expected_hash"`
+	ActualHash   string `json:"actual_hash"`
+	Error        string `json:"error,omitempty"`
+}
+
+func (rr ReplayResult) IsConsistent() bool {
+	return rr.Divergence == nil
+}
+
+func (rd ReplayDivergence) String() string {
+	if rd.Error != "" {
+		return fmt.Sprintf("step %d (%s): %s", rd.Step, rd.Action, rd.Error)
+	}
+	return fmt.Sprintf("step %d (%s): expected state %s, got %s", rd.Step, rd.Action, rd.ExpectedHash, rd.ActualHash)
+}
+
+// ReplayHand re-drives a fresh game on backend with the recorded options and steps.
+func ReplayHand(backend GameBackend, record *HandRecord) (*ReplayResult, error) {
+	if record == nil || record.Options == nil {
+		return nil, ErrReplayInvalidHandRecord
+	}
+
+	opts, err := cloneGameOptions(record.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReplayResult{
+		GameID: record.GameID,
+	}
+
+	gs, err := backend.CreateGame(opts)
+	if err != nil {
+		return nil, err
+	}
+	result.FinalState = gs
+
+	if hash := GameStateHash(gs); hash != record.InitialStateHash {
+		result.Divergence = &ReplayDivergence{
+			Step:         UnsetValue,
+			ExpectedHash: record.InitialStateHash,
+			ActualHash:   hash,
+		}
+		return result, nil
+	}
+
+	for stepIdx, step := range record.Steps {
+		newState, err := replayStep(backend, gs, step)
+		if err != nil {
+			result.Divergence = &ReplayDivergence{
+				Step:         stepIdx,
+				Action:       step.Action,
+				ExpectedHash: step.StateHash,
+				Error:        err.Error(),
+			}
+			return result, nil
+		}
+
+		gs = newState
+		result.FinalState = gs
+		result.ReplayedSteps++
+
+		if hash := GameStateHash(gs); hash != step.StateHash {
+			result.Divergence = &ReplayDivergence{
+				Step:         stepIdx,
+				Action:       step.Action,
+				ExpectedHash: step.StateHash,
+				ActualHash:   hash,
+			}
+			return result, nil
+		}
+	}
+
+	return result, nil
+}
+
+func replayStep(backend GameBackend, gs *pokerface.GameState, step *HandRecordStep) (*pokerface.GameState, error) {
+	if step.GamePlayerIdx != gs.Status.CurrentPlayer {
+		switch step.Action {
+		case ReplayAction_Pay, ReplayAction_Fold, ReplayAction_Check, ReplayAction_Call, ReplayAction_Allin, ReplayAction_Bet, ReplayAction_Raise, ReplayAction_Pass:
+			return nil, fmt.Errorf("replay: current player is %d instead of %d", gs.Status.CurrentPlayer, step.GamePlayerIdx)
+		}
+	}
+
+	switch step.Action {
+	case ReplayAction_ReadyForAll:
+		return backend.ReadyForAll(gs)
+	case ReplayAction_PayAnte:
+		return backend.PayAnte(gs)
+	case ReplayAction_PayBlinds:
+		return backend.PayBlinds(gs)
+	case ReplayAction_Next:
+		return backend.Next(gs)
+	case ReplayAction_Pay:
+		return backend.Pay(gs, step.Chips)
+	case ReplayAction_Fold:
+		return backend.Fold(gs)
+	case ReplayAction_Check:
+		return backend.Check(gs)
+	case ReplayAction_Call:
+		return backend.Call(gs)
+	case ReplayAction_Allin:
+		return backend.Allin(gs)
+	case ReplayAction_Bet:
+		return backend.Bet(gs, step.Chips)
+	case ReplayAction_Raise:
+		return backend.Raise(gs, step.Chips)
+	case ReplayAction_Pass:
+		return backend.Pass(gs)
+	}
+
+	return nil, ErrReplayUnknownAction
+}
+
+// GameStateHash hashes game state without game ID and timestamps which are different in every run.
+func GameStateHash(gs *pokerface.GameState) string {
+	state := cloneGameState(gs)
+	if state == nil {
+		return ""
+	}
+
+	state.GameID = ""
+	state.CreatedAt = 0
+	state.UpdatedAt = 0
+
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,228 @@
+package pwbtable
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/weedbox/pokerface"
+)
+
+var (
+	ErrRuleSetNotFound   = errors.New("rule: rule set not found")
+	ErrRuleSetDuplicated = errors.New("rule: rule set already registered")
+)
+
+// RuleSet is a poker variant which table is able to play, table finds it by TableMeta.Rule.
+type RuleSet interface {
+	Name() string
+
+	// NewDeck returns all cards of the deck in any order, table engine sorts and shuffles them.
+	NewDeck() []string
+	HoleCardsCount() int
+	RequiredHoleCardsCount() int // 0 means any of hole cards is able to be used
+
+	// GameOptions returns game options of the variant, deck, limit, forced bets and players are filled by table engine.
+	GameOptions() *pokerface.GameOptions
+
+	// ForcedBets decides ante and blinds of a hand from blind state of table.
+	ForcedBets(blind TableBlindState) (int64, pokerface.BlindSetting)
+
+	// Positions returns positions of game players from dealer.
+	Positions(playerCount int) [][]string
+
+	// IsBetweenDealerBBWaiting tells whether new player seated between dealer and big blind waits for the button to pass.
+	IsBetweenDealerBBWaiting() bool
+
+	// BettingLimit is used when TableMeta.Limit is empty.
+	BettingLimit() string
+}
+
+type RuleSetOpt func(*ruleSet)
+
+type ruleSet struct {
+	name                     string
+	newDeck                  func() []string
+	holeCardsCount           int
+	requiredHoleCardsCount   int
+	newGameOptions           func() *pokerface.GameOptions
+	forcedBets               func(blind TableBlindState) (int64, pokerface.BlindSetting)
+	positions                func(playerCount int) [][]string
+	isBetweenDealerBBWaiting bool
+	bettingLimit             string
+}
+
+// NewRuleSet creates rule set which plays no-limit hold'em unless it is changed by options.
+func NewRuleSet(name string, opts ...RuleSetOpt) RuleSet {
+	rs := &ruleSet{
+		name:                     name,
+		newDeck:                  pokerface.NewStandardDeckCards,
+		holeCardsCount:           2,
+		requiredHoleCardsCount:   0,
+		newGameOptions:           pokerface.NewStardardGameOptions,
+		forcedBets:               blindForcedBets,
+		positions:                newPositions,
+		isBetweenDealerBBWaiting: true,
+		bettingLimit:             BettingLimit_NoLimit,
+	}
+
+	for _, opt := range opts {
+		opt(rs)
+	}
+
+	return rs
+}
+
+func WithRuleDeck(fn func() []string) RuleSetOpt {
+	return func(rs *ruleSet) {
+		rs.newDeck = fn
+	}
+}
+
+func WithRuleHoleCards(count, requiredCount int) RuleSetOpt {
+	return func(rs *ruleSet) {
+		rs.holeCardsCount = count
+		rs.requiredHoleCardsCount = requiredCount
+	}
+}
+
+func WithRuleGameOptions(fn func() *pokerface.GameOptions) RuleSetOpt {
+	return func(rs *ruleSet) {
+		rs.newGameOptions = fn
+	}
+}
+
+func WithRuleForcedBets(fn func(blind TableBlindState) (int64, pokerface.BlindSetting)) RuleSetOpt {
+	return func(rs *ruleSet) {
+		rs.forcedBets = fn
+	}
+}
+
+func WithRulePositions(fn func(playerCount int) [][]string) RuleSetOpt {
+	return func(rs *ruleSet) {
+		rs.positions = fn
+	}
+}
+
+func WithRuleBetweenDealerBBWaiting(isWaiting bool) RuleSetOpt {
+	return func(rs *ruleSet) {
+		rs.isBetweenDealerBBWaiting = isWaiting
+	}
+}
+
+func WithRuleBettingLimit(limit string) RuleSetOpt {
+	return func(rs *ruleSet) {
+		rs.bettingLimit = limit
+	}
+}
+
+func (rs *ruleSet) Name() string {
+	return rs.name
+}
+
+func (rs *ruleSet) NewDeck() []string {
+	return rs.newDeck()
+}
+
+func (rs *ruleSet) HoleCardsCount() int {
+	return rs.holeCardsCount
+}
+
+func (rs *ruleSet) RequiredHoleCardsCount() int {
+	return rs.requiredHoleCardsCount
+}
+
+func (rs *ruleSet) GameOptions() *pokerface.GameOptions {
+	opts := rs.newGameOptions()
+	opts.HoleCardsCount = rs.holeCardsCount
+	opts.RequiredHoleCardsCount = rs.requiredHoleCardsCount
+	return opts
+}
+
+func (rs *ruleSet) ForcedBets(blind TableBlindState) (int64, pokerface.BlindSetting) {
+	return rs.forcedBets(blind)
+}
+
+func (rs *ruleSet) Positions(playerCount int) [][]string {
+	return rs.positions(playerCount)
+}
+
+func (rs *ruleSet) IsBetweenDealerBBWaiting() bool {
+	return rs.isBetweenDealerBBWaiting
+}
+
+func (rs *ruleSet) BettingLimit() string {
+	return rs.bettingLimit
+}
+
+func blindForcedBets(blind TableBlindState) (int64, pokerface.BlindSetting) {
+	return blind.Ante, pokerface.BlindSetting{
+		Dealer: blind.Dealer,
+		SB:     blind.SB,
+		BB:     blind.BB,
+	}
+}
+
+var (
+	ruleSetsLock sync.RWMutex
+	ruleSets     = newBuiltinRuleSets()
+)
+
+func newBuiltinRuleSets() map[string]RuleSet {
+	builtinRuleSets := []RuleSet{
+		NewRuleSet(CompetitionRule_Default),
+		NewRuleSet(CompetitionRule_ShortDeck,
+			WithRuleDeck(pokerface.NewShortDeckCards),
+			WithRuleGameOptions(pokerface.NewShortDeckGameOptions),
+			WithRuleBetweenDealerBBWaiting(false),
+		),
+		NewRuleSet(CompetitionRule_Omaha, WithRuleHoleCards(4, 2), WithRuleBettingLimit(BettingLimit_PotLimit)),
+		NewRuleSet(CompetitionRule_OmahaHiLo, WithRuleHoleCards(4, 2), WithRuleBettingLimit(BettingLimit_PotLimit)),
+		NewRuleSet(CompetitionRule_Omaha5, WithRuleHoleCards(5, 2), WithRuleBettingLimit(BettingLimit_PotLimit)),
+		NewRuleSet(CompetitionRule_Omaha6, WithRuleHoleCards(6, 2), WithRuleBettingLimit(BettingLimit_PotLimit)),
+	}
+
+	ruleSets := make(map[string]RuleSet)
+	for _, rs := range builtinRuleSets {
+		ruleSets[rs.Name()] = rs
+	}
+	return ruleSets
+}
+
+// RegisterRuleSet adds a variant which tables are able to play by its name.
+func RegisterRuleSet(rs RuleSet) error {
+	ruleSetsLock.Lock()
+	defer ruleSetsLock.Unlock()
+
+	if _, exist := ruleSets[rs.Name()]; exist {
+		return ErrRuleSetDuplicated
+	}
+
+	ruleSets[rs.Name()] = rs
+	return nil
+}
+
+// GetRuleSet finds registered variant, empty name is the default rule.
+func GetRuleSet(name string) (RuleSet, error) {
+	if name == "" {
+		name = CompetitionRule_Default
+	}
+
+	ruleSetsLock.RLock()
+	defer ruleSetsLock.RUnlock()
+
+	rs, exist := ruleSets[name]
+	if !exist {
+		return nil, ErrRuleSetNotFound
+	}
+	return rs, nil
+}
+
+// ruleSetOf falls back to the default rule for unknown rule, tables with unknown rule are rejected by CreateTable.
+func ruleSetOf(name string) RuleSet {
+	if rs, err := GetRuleSet(name); err == nil {
+		return rs
+	}
+
+	rs, _ := GetRuleSet(CompetitionRule_Default)
+	return rs
+}
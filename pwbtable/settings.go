@@ -7,7 +7,9 @@ type TableSetting struct {
 }
 
 type JoinPlayer struct {
-	PlayerID    string `json:"player_id"`
-	RedeemChips int64  `json:"redeem_chips"`
-	Seat        int    `json:"seat"`
+	PlayerID    string            `json:"player_id"`
+	RedeemChips int64             `json:"redeem_chips"`
+	Seat        int               `json:"seat"`
+	Entry       *TablePlayerEntry `json:"entry,omitempty"` // rebuy, add-on and re-entry counts of player moved from another table
+	State       *TablePlayerState `json:"state,omitempty"` // state of player moved from another table, time bank, sit-out and auto mode are kept
 }
@@ -0,0 +1,122 @@
+package pwbtable
+
+import (
+	"sort"
+	"time"
+)
+
+type TableSitAndGoSetting struct {
+	Payout PayoutSetting `json:"payout"` // entrant count and chip unit are decided by table
+}
+
+type TableStanding struct {
+	PlayerID   string `json:"player_id"`
+	Place      int    `json:"place"`
+	Prize      int64  `json:"prize"`
+	FinishedAt int64  `json:"finished_at"`
+}
+
+func (s TableSitAndGoSetting) Validate() error {
+	// no prize
+	if s.Payout.Structure.Type == "" {
+		return nil
+	}
+	return s.Payout.Validate()
+}
+
+func (t Table) IsSitAndGoFinished() bool {
+	if t.Meta.Mode != CompetitionMode_SitAndGo {
+		return false
+	}
+
+	for _, standing := range t.State.Standings {
+		if standing.Place == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// startSitAndGo lets all players in and starts the game once the table is full.
+func (te *tableEngine) startSitAndGo() {
+	if te.table.Meta.Mode != CompetitionMode_SitAndGo || te.table.State.StartAt != UnsetValue {
+		return
+	}
+
+	if len(te.table.State.PlayerStates) < te.table.Meta.TableMaxSeatCount {
+		return
+	}
+
+	te.playersAutoIn()
+}
+
+// updateStandings gives eliminated players their finishing places, the last one is the winner.
+func (te *tableEngine) updateStandings(eliminatedPlayers []*TablePlayerState) {
+	if te.table.Meta.Mode != CompetitionMode_SitAndGo || len(eliminatedPlayers) == 0 {
+		return
+	}
+
+	now := time.Now().Unix()
+	alivePlayers := te.table.AlivePlayers()
+	bustedPlayers := make([]PayoutBustedPlayer, 0, len(eliminatedPlayers))
+	for _, player := range eliminatedPlayers {
+		bustedPlayers = append(bustedPlayers, PayoutBustedPlayer{
+			PlayerID:      player.PlayerID,
+			StartingStack: te.table.StartingStackSize(player.PlayerID),
+		})
+	}
+
+	for _, place := range RankBustedPlayers(bustedPlayers, len(alivePlayers)+len(eliminatedPlayers)) {
+		te.table.State.Standings = append(te.table.State.Standings, &TableStanding{
+			PlayerID:   place.PlayerID,
+			Place:      place.Place,
+			FinishedAt: now,
+		})
+	}
+
+	if len(alivePlayers) != 1 {
+		return
+	}
+
+	te.table.State.Standings = append(te.table.State.Standings, &TableStanding{
+		PlayerID:   alivePlayers[0].PlayerID,
+		Place:      1,
+		FinishedAt: now,
+	})
+
+	sort.SliceStable(te.table.State.Standings, func(i, j int) bool {
+		return te.table.State.Standings[i].Place < te.table.State.Standings[j].Place
+	})
+
+	if err := te.payStandings(); err != nil {
+		te.emitErrorEvent(TableEventKind_SitAndGoFinished, "", err)
+	}
+
+	te.emitEvent(TableEventKind_SitAndGoFinished, "", te.table.State.Standings)
+}
+
+func (te *tableEngine) payStandings() error {
+	if te.table.Meta.SitAndGo.Payout.Structure.Type == "" {
+		return nil
+	}
+
+	payout := te.table.Meta.SitAndGo.Payout
+	payout.EntrantCount = len(te.table.State.Standings)
+	payout.MinChipUnit = te.table.Meta.MinChipUnit
+	prizes, err := payout.Prizes()
+	if err != nil {
+		return err
+	}
+
+	places := make([]*PayoutPlace, 0, len(te.table.State.Standings))
+	for _, standing := range te.table.State.Standings {
+		places = append(places, &PayoutPlace{PlayerID: standing.PlayerID, Place: standing.Place})
+	}
+
+	playerPrizes := SplitPrizes(prizes, places, payout.MinChipUnit)
+	for _, standing := range te.table.State.Standings {
+		standing.Prize = playerPrizes[standing.PlayerID]
+	}
+
+	return nil
+}
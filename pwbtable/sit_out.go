@@ -0,0 +1,273 @@
+package pwbtable
+
+import (
+	"time"
+
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/pokerface/settlement"
+)
+
+type TableSitOutSetting struct {
+	MaxTimeouts int `json:"max_timeouts"` // player sits out after consecutive action timeouts, 0 disables
+	MaxDuration int `json:"max_duration"` // seconds, player sitting out longer is evicted between hands, 0 disables
+}
+
+func (s TableSitOutSetting) Validate() error {
+	if s.MaxTimeouts < 0 || s.MaxDuration < 0 {
+		return ErrTableInvalidCreateSetting
+	}
+	return nil
+}
+
+// ActivePlayers returns players who are able to be dealt in.
+func (t Table) ActivePlayers() []*TablePlayerState {
+	return funk.Filter(t.State.PlayerStates, func(player *TablePlayerState) bool {
+		return player.Bankroll > 0 && !player.IsSittingOut
+	}).([]*TablePlayerState)
+}
+
+func (te *tableEngine) PlayerSitOut(playerID string) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if te.table.Meta.Mode != CompetitionMode_Cash {
+		return ErrTablePlayerInvalidAction
+	}
+
+	playerIdx := te.table.FindPlayerIdx(playerID)
+	if playerIdx == UnsetValue {
+		return ErrTablePlayerNotFound
+	}
+
+	playerState := te.table.State.PlayerStates[playerIdx]
+	if playerState.IsSittingOut {
+		return nil
+	}
+
+	te.sitOut(playerState)
+	return nil
+}
+
+// PlayerSitIn brings player back from next hand, player who missed big blind either posts it as dead blind or waits for big blind.
+func (te *tableEngine) PlayerSitIn(playerID string, isWaitingBB bool) error {
+	te.lock.Lock()
+	defer te.lock.Unlock()
+
+	if te.table.Meta.Mode != CompetitionMode_Cash {
+		return ErrTablePlayerInvalidAction
+	}
+
+	playerIdx := te.table.FindPlayerIdx(playerID)
+	if playerIdx == UnsetValue {
+		return ErrTablePlayerNotFound
+	}
+
+	playerState := te.table.State.PlayerStates[playerIdx]
+	if !playerState.IsSittingOut {
+		return nil
+	}
+
+	playerState.IsSittingOut = false
+	playerState.SitOutAt = UnsetValue
+	playerState.TimeoutCount = 0
+	if isWaitingBB && playerState.MissedBlinds > 0 {
+		playerState.MissedBlinds = 0
+		playerState.IsWaitingBB = true
+	}
+	te.emitTablePlayerStateEvent(playerState)
+	te.emitEvent(TableEventKind_PlayerSatIn, playerID, isWaitingBB)
+
+	// paused table is able to be resumed once enough players are back
+	if te.table.State.Status == TableStateStatus_TablePausing && te.table.State.StartAt != UnsetValue && !te.table.ShouldPause() {
+		te.table.State.Status = TableStateStatus_TableGameStandby
+		go func() {
+			if err := te.TableGameOpen(); err != nil {
+				te.emitErrorEvent(TableEventKind_TableGameOpened, "", err)
+			}
+		}()
+	}
+
+	return nil
+}
+
+func (te *tableEngine) sitOut(playerState *TablePlayerState) {
+	playerState.IsSittingOut = true
+	playerState.IsWaitingBB = false
+	playerState.SitOutAt = time.Now().Unix()
+	te.emitTablePlayerStateEvent(playerState)
+	te.emitEvent(TableEventKind_PlayerSatOut, playerState.PlayerID, nil)
+}
+
+// countActionTimeout sits player out after too many consecutive timeouts, count is reset by any action of the player.
+func (te *tableEngine) countActionTimeout(playerID string, timeoutCount int) {
+	playerIdx := te.table.FindPlayerIdx(playerID)
+	if playerIdx == UnsetValue {
+		return
+	}
+
+	playerState := te.table.State.PlayerStates[playerIdx]
+	playerState.TimeoutCount = timeoutCount
+
+	maxTimeouts := te.table.Meta.SitOut.MaxTimeouts
+	if te.table.Meta.Mode != CompetitionMode_Cash || maxTimeouts <= 0 || timeoutCount < maxTimeouts || playerState.IsSittingOut {
+		return
+	}
+
+	te.sitOut(playerState)
+}
+
+func (te *tableEngine) resetActionTimeouts(playerID string) {
+	playerIdx := te.table.FindPlayerIdx(playerID)
+	if playerIdx == UnsetValue {
+		return
+	}
+	te.table.State.PlayerStates[playerIdx].TimeoutCount = 0
+}
+
+// evictSittingOutPlayers lets players who sit out too long leave table between hands.
+func (te *tableEngine) evictSittingOutPlayers() {
+	maxDuration := te.table.Meta.SitOut.MaxDuration
+	if te.table.Meta.Mode != CompetitionMode_Cash || maxDuration <= 0 {
+		return
+	}
+
+	now := time.Now().Unix()
+	playerIDs := make([]string, 0)
+	for _, player := range te.table.State.PlayerStates {
+		if player.IsSittingOut && now >= player.SitOutAt+int64(maxDuration) {
+			playerIDs = append(playerIDs, player.PlayerID)
+		}
+	}
+
+	if len(playerIDs) == 0 {
+		return
+	}
+
+	te.leavePlayers(playerIDs)
+}
+
+// waitShortMissedBlinds lets returning players who are short of chips for missed big blind wait for big blind instead.
+func (t Table) waitShortMissedBlinds() {
+	for _, player := range t.State.PlayerStates {
+		if player.IsSittingOut || !player.IsIn || player.MissedBlinds == 0 || player.Bankroll > player.MissedBlinds {
+			continue
+		}
+
+		player.IsWaitingBB = true
+		player.MissedBlinds = 0
+	}
+}
+
+// postMissedBlinds takes missed big blind from returning players who are dealt in as dead blinds, the big blind of the hand owes nothing.
+func (t Table) postMissedBlinds() {
+	_, bbSeat := t.blindSeats(t.State.GamePlayerIndexes)
+	for _, playerIdx := range t.State.GamePlayerIndexes {
+		player := t.State.PlayerStates[playerIdx]
+		if player.MissedBlinds == 0 {
+			continue
+		}
+
+		if player.Seat != bbSeat {
+			player.Bankroll -= player.MissedBlinds
+			t.State.DeadBlinds += player.MissedBlinds
+		}
+		player.MissedBlinds = 0
+	}
+}
+
+// seatWaitingBBPlayer deals in the first waiting player who would be big blind, it returns whether game players are changed.
+func (t Table) seatWaitingBBPlayer(gamePlayerIndexes []int) bool {
+	if len(gamePlayerIndexes) < 2 {
+		return false
+	}
+
+	// waiting player after small blind of the hand with one more player becomes big blind,
+	// it is the player after big blind in heads-up since dealer is small blind
+	sbSeat := t.State.PlayerStates[gamePlayerIndexes[1]].Seat
+	bbSeat := t.State.PlayerStates[gamePlayerIndexes[2%len(gamePlayerIndexes)]].Seat
+
+	for i := sbSeat + 1; i < sbSeat+t.Meta.TableMaxSeatCount; i++ {
+		seat := i % t.Meta.TableMaxSeatCount
+		if seat == bbSeat {
+			return false
+		}
+
+		playerIdx := t.State.SeatMap[seat]
+		if playerIdx == UnsetValue {
+			continue
+		}
+
+		player := t.State.PlayerStates[playerIdx]
+		if player.IsWaitingBB && player.IsIn && !player.IsSittingOut && player.Bankroll > 0 {
+			player.IsWaitingBB = false
+			player.IsParticipated = true
+			return true
+		}
+	}
+	return false
+}
+
+// trackMissedBlinds charges big blind to players sitting out whom big blind passes.
+func (t Table) trackMissedBlinds(gamePlayerIndexes []int) {
+	sbSeat, bbSeat := t.blindSeats(gamePlayerIndexes)
+	if sbSeat == UnsetValue {
+		return
+	}
+
+	for i := sbSeat + 1; i < sbSeat+t.Meta.TableMaxSeatCount; i++ {
+		seat := i % t.Meta.TableMaxSeatCount
+		if seat == bbSeat {
+			return
+		}
+
+		playerIdx := t.State.SeatMap[seat]
+		if playerIdx == UnsetValue {
+			continue
+		}
+
+		player := t.State.PlayerStates[playerIdx]
+		if player.IsSittingOut {
+			player.MissedBlinds = t.State.BlindState.BB
+		}
+	}
+}
+
+func (t Table) blindSeats(gamePlayerIndexes []int) (int, int) {
+	switch {
+	case len(gamePlayerIndexes) == 2:
+		return t.State.PlayerStates[gamePlayerIndexes[0]].Seat, t.State.PlayerStates[gamePlayerIndexes[1]].Seat
+	case len(gamePlayerIndexes) > 2:
+		return t.State.PlayerStates[gamePlayerIndexes[1]].Seat, t.State.PlayerStates[gamePlayerIndexes[2]].Seat
+	}
+	return UnsetValue, UnsetValue
+}
+
+// settleDeadBlinds adds dead blinds to main pot and gives them to its winners.
+func (te *tableEngine) settleDeadBlinds(result *settlement.Result) {
+	// dead blinds never carry over to the next hand
+	deadBlinds := te.table.State.DeadBlinds
+	te.table.State.DeadBlinds = 0
+	if deadBlinds == 0 || len(result.Pots) == 0 || len(result.Pots[0].Winners) == 0 {
+		return
+	}
+
+	pot := result.Pots[0]
+	pot.Total += deadBlinds
+
+	// odd chips go to the first winner
+	shares := make(map[int]int64)
+	share := deadBlinds / int64(len(pot.Winners))
+	for _, winner := range pot.Winners {
+		shares[winner.Idx] += share
+	}
+	shares[pot.Winners[0].Idx] += deadBlinds - share*int64(len(pot.Winners))
+
+	for _, winner := range pot.Winners {
+		winner.Withdraw += shares[winner.Idx]
+	}
+
+	for _, player := range result.Players {
+		player.Final += shares[player.Idx]
+		player.Changed += shares[player.Idx]
+	}
+}
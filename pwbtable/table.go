@@ -32,14 +32,32 @@ type Table struct {
 }
 
 type TableMeta struct {
-	CompetitionID       string `json:"competition_id"`
-	Rule                string `json:"rule"`
-	Mode                string `json:"mode"`
-	MaxDuration         int    `json:"max_duration"`
-	TableMaxSeatCount   int    `json:"table_max_seat_count"`
-	TableMinPlayerCount int    `json:"table_min_player_count"`
-	MinChipUnit         int64  `json:"min_chip_unit"`
-	ActionTime          int    `json:"action_time"`
+	CompetitionID       string                 `json:"competition_id"`
+	Rule                string                 `json:"rule"`
+	Limit               string                 `json:"limit"` // betting limit, decided by rule if empty
+	FixedLimit          TableFixedLimitSetting `json:"fixed_limit"`
+	Mode                string                 `json:"mode"`
+	MaxDuration         int                    `json:"max_duration"`
+	TableMaxSeatCount   int                    `json:"table_max_seat_count"`
+	TableMinPlayerCount int                    `json:"table_min_player_count"`
+	MinChipUnit         int64                  `json:"min_chip_unit"`
+	ActionTime          int                    `json:"action_time"`
+	TimeBank            TableTimeBankSetting   `json:"time_bank"`
+	BlindStructure      TableBlindStructure    `json:"blind_structure"`
+	SitAndGo            TableSitAndGoSetting   `json:"sit_and_go"`
+	ReBuy               TableReBuySetting      `json:"rebuy"`
+	AddOn               TableAddOnSetting      `json:"add_on"`
+	ReEntry             TableReEntrySetting    `json:"re_entry"`
+	Cash                TableCashSetting       `json:"cash"`
+	Rake                TableRakeSetting       `json:"rake"`
+	SitOut              TableSitOutSetting     `json:"sit_out"`
+}
+
+type TableTimeBankSetting struct {
+	InitialSeconds   int `json:"initial_seconds"`
+	MaxSeconds       int `json:"max_seconds"`
+	ReplenishSeconds int `json:"replenish_seconds"`
+	ReplenishHands   int `json:"replenish_hands"`
 }
 
 type TableState struct {
@@ -53,6 +71,14 @@ type TableState struct {
 	GameCount         int                  `json:"game_count"`
 	GamePlayerIndexes []int                `json:"game_player_indexes"`
 	GameState         *pokerface.GameState `json:"game_state"`
+	ActionEndAt       int64                `json:"action_end_at"`
+	Standings         []*TableStanding     `json:"standings"`
+	PlayerEntries     []*TablePlayerEntry  `json:"player_entries"`
+	LeftPlayers       []*TableLeftPlayer   `json:"left_players"`
+	Rake              TableRakeState       `json:"rake"`
+	DeadBlinds        int64                `json:"dead_blinds"`
+	SplitPots         []TableSplitPot      `json:"split_pots"`
+	FixedLimit        TableFixedLimitState `json:"fixed_limit"`
 }
 
 type TablePlayerGameAction struct {
@@ -81,7 +107,16 @@ type TablePlayerState struct {
 	IsParticipated    bool                      `json:"is_participated"`
 	IsBetweenDealerBB bool                      `json:"is_between_dealer_bb"`
 	Bankroll          int64                     `json:"bankroll"`
+	PendingTopUp      int64                     `json:"pending_top_up"`
 	IsIn              bool                      `json:"is_in"`
+	IsSittingOut      bool                      `json:"is_sitting_out"`
+	SitOutAt          int64                     `json:"sit_out_at"`
+	IsWaitingBB       bool                      `json:"is_waiting_bb"`
+	MissedBlinds      int64                     `json:"missed_blinds"`
+	TimeoutCount      int                       `json:"timeout_count"`
+	IsAutoMode        bool                      `json:"is_auto_mode"`
+	TimeBank          int                       `json:"time_bank"`
+	TimeBankHandCount int                       `json:"time_bank_hand_count"`
 	GameStatistics    TablePlayerGameStatistics `json:"game_statistics"`
 }
 
@@ -95,11 +130,16 @@ type TablePlayerGameStatistics struct {
 }
 
 type TableBlindState struct {
-	Level  int   `json:"level"`
-	Ante   int64 `json:"ante"`
-	Dealer int64 `json:"dealer"`
-	SB     int64 `json:"sb"`
-	BB     int64 `json:"bb"`
+	Level              int   `json:"level"`
+	Ante               int64 `json:"ante"`
+	Dealer             int64 `json:"dealer"`
+	SB                 int64 `json:"sb"`
+	BB                 int64 `json:"bb"`
+	SmallBet           int64 `json:"small_bet"` // fixed limit bet before the turn, big blind if 0
+	BigBet             int64 `json:"big_bet"`   // fixed limit bet since the turn, double big blind if 0
+	LevelIndex         int   `json:"level_index"`
+	NextLevelAt        int64 `json:"next_level_at"`
+	NextLevelGameCount int   `json:"next_level_game_count"`
 }
 
 func (t Table) Clone() (*Table, error) {
@@ -194,6 +234,22 @@ func (t Table) FindPlayerIdx(playerID string) int {
 	return UnsetValue
 }
 
+// StartingStackSize returns stack of player at the beginning of the settled hand.
+func (t Table) StartingStackSize(playerID string) int64 {
+	gs := t.State.GameState
+	gamePlayerIdx := t.FindGamePlayerIdx(playerID)
+	if gs == nil || gs.Result == nil || gamePlayerIdx == UnsetValue {
+		return 0
+	}
+
+	for _, player := range gs.Result.Players {
+		if player.Idx == gamePlayerIdx {
+			return player.Final - player.Changed
+		}
+	}
+	return 0
+}
+
 func (t Table) PlayerSeatMap() map[string]int {
 	playerSeatMap := make(map[string]int)
 	for _, player := range t.State.PlayerStates {
@@ -203,7 +259,23 @@ func (t Table) PlayerSeatMap() map[string]int {
 }
 
 func (t Table) ShouldPause() bool {
-	return t.State.BlindState.IsBreaking() || len(t.AlivePlayers()) < t.Meta.TableMinPlayerCount
+	return t.State.BlindState.IsBreaking() || len(t.ActivePlayers()) < t.Meta.TableMinPlayerCount
+}
+
+func (tbs TableTimeBankSetting) Replenish(playerState *TablePlayerState) {
+	if tbs.ReplenishSeconds <= 0 || tbs.ReplenishHands <= 0 {
+		return
+	}
+
+	playerState.TimeBankHandCount++
+	if playerState.TimeBankHandCount%tbs.ReplenishHands != 0 {
+		return
+	}
+
+	playerState.TimeBank += tbs.ReplenishSeconds
+	if tbs.MaxSeconds > 0 && playerState.TimeBank > tbs.MaxSeconds {
+		playerState.TimeBank = tbs.MaxSeconds
+	}
 }
 
 func (bs TableBlindState) IsBreaking() bool {
@@ -0,0 +1,234 @@
+package pwbtable
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+type TableEventKind string
+
+const (
+	TableEventKind_TableCreated          TableEventKind = "table_created"
+	TableEventKind_TablePlayersAutoAdded TableEventKind = "table_players_auto_added"
+	TableEventKind_TablePaused           TableEventKind = "table_paused"
+	TableEventKind_TableClosed           TableEventKind = "table_closed"
+	TableEventKind_TableRestored         TableEventKind = "table_restored"
+	TableEventKind_TableGameStarted      TableEventKind = "table_game_started"
+	TableEventKind_TableGameOpened       TableEventKind = "table_game_opened"
+	TableEventKind_TableGameSettled      TableEventKind = "table_game_settled"
+	TableEventKind_TableGameStandby      TableEventKind = "table_game_standby"
+	TableEventKind_GameStateUpdated      TableEventKind = "game_state_updated"
+	TableEventKind_PlayerReserved        TableEventKind = "player_reserved"
+	TableEventKind_PlayerJoined          TableEventKind = "player_joined"
+	TableEventKind_PlayerChipsRedeemed   TableEventKind = "player_chips_redeemed"
+	TableEventKind_PlayerAddedOn         TableEventKind = "player_added_on"
+	TableEventKind_PlayerSatOut          TableEventKind = "player_sat_out"
+	TableEventKind_PlayerSatIn           TableEventKind = "player_sat_in"
+	TableEventKind_PlayerAutoModeUpdated TableEventKind = "player_auto_mode_updated"
+	TableEventKind_PlayersLeft           TableEventKind = "players_left"
+	TableEventKind_PlayerActionTimeout   TableEventKind = "player_action_timeout"
+	TableEventKind_PlayerTimeExtended    TableEventKind = "player_time_extended"
+	TableEventKind_BlindLevelUpdated     TableEventKind = "blind_level_updated"
+	TableEventKind_PlayersEliminated     TableEventKind = "players_eliminated"
+	TableEventKind_SitAndGoFinished      TableEventKind = "sit_and_go_finished"
+)
+
+type TableEventBackPressure int
+
+const (
+	// TableEventBackPressure_DropOldest discards the oldest buffered event to make room, it is the default
+	TableEventBackPressure_DropOldest TableEventBackPressure = iota
+	// TableEventBackPressure_DropNewest discards the incoming event when the buffer is full
+	TableEventBackPressure_DropNewest
+	// TableEventBackPressure_Block waits until the subscriber has room for the event,
+	// the publishing goroutine stalls until the subscriber drains its buffer, it is the table lock holder for player actions
+	// and the game updater without the lock for game state and settlement events
+	TableEventBackPressure_Block
+)
+
+type TableEvent struct {
+	Kind      TableEventKind `json:"kind"`
+	Serial    int64          `json:"serial"`
+	TableID   string         `json:"table_id"`
+	GameCount int            `json:"game_count"`
+	PlayerID  string         `json:"player_id"`
+	Payload   interface{}    `json:"payload,omitempty"`
+	Error     string         `json:"error,omitempty"`
+	CreatedAt int64          `json:"created_at"`
+}
+
+type TableEventSubscriptionOptions struct {
+	BufferSize   int
+	BackPressure TableEventBackPressure
+}
+
+func NewTableEventSubscriptionOptions() *TableEventSubscriptionOptions {
+	return &TableEventSubscriptionOptions{
+		BufferSize:   256,
+		BackPressure: TableEventBackPressure_DropOldest,
+	}
+}
+
+type TableEventSubscription interface {
+	ID() string
+	Events() <-chan *TableEvent
+	Dropped() int64
+	Unsubscribe()
+}
+
+type tableEventSubscription struct {
+	id       string
+	hub      *tableEventHub
+	options  TableEventSubscriptionOptions
+	events   chan *TableEvent
+	done     chan struct{}
+	once     sync.Once
+	mu       sync.RWMutex
+	isClosed bool
+	dropped  int64
+}
+
+func (s *tableEventSubscription) ID() string {
+	return s.id
+}
+
+func (s *tableEventSubscription) Events() <-chan *TableEvent {
+	return s.events
+}
+
+func (s *tableEventSubscription) Dropped() int64 {
+	return atomic.LoadInt64(&s.dropped)
+}
+
+func (s *tableEventSubscription) Unsubscribe() {
+	s.hub.remove(s.id)
+	s.close()
+}
+
+func (s *tableEventSubscription) close() {
+	s.once.Do(func() {
+		close(s.done)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.isClosed = true
+		close(s.events)
+	})
+}
+
+func (s *tableEventSubscription) deliver(event *TableEvent) {
+	switch s.options.BackPressure {
+	case TableEventBackPressure_Block:
+		// done is closed before events, so a blocked delivery is released by Unsubscribe
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		if s.isClosed {
+			return
+		}
+
+		select {
+		case s.events <- event:
+		case <-s.done:
+		}
+	case TableEventBackPressure_DropNewest:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.isClosed {
+			return
+		}
+
+		select {
+		case s.events <- event:
+		default:
+			atomic.AddInt64(&s.dropped, 1)
+		}
+	default:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.isClosed {
+			return
+		}
+
+		for {
+			select {
+			case s.events <- event:
+				return
+			default:
+			}
+
+			select {
+			case <-s.events:
+				atomic.AddInt64(&s.dropped, 1)
+			default:
+			}
+		}
+	}
+}
+
+type tableEventHub struct {
+	mu            sync.RWMutex
+	subscriptions map[string]*tableEventSubscription
+}
+
+func newTableEventHub() *tableEventHub {
+	return &tableEventHub{
+		subscriptions: make(map[string]*tableEventSubscription),
+	}
+}
+
+func (h *tableEventHub) subscribe(options *TableEventSubscriptionOptions) *tableEventSubscription {
+	if options == nil {
+		options = NewTableEventSubscriptionOptions()
+	}
+
+	bufferSize := options.BufferSize
+	if bufferSize <= 0 && options.BackPressure != TableEventBackPressure_Block {
+		bufferSize = 1
+	}
+
+	s := &tableEventSubscription{
+		id:      uuid.New().String(),
+		hub:     h,
+		options: *options,
+		events:  make(chan *TableEvent, bufferSize),
+		done:    make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	h.subscriptions[s.id] = s
+	h.mu.Unlock()
+
+	return s
+}
+
+func (h *tableEventHub) remove(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscriptions, id)
+}
+
+func (h *tableEventHub) publish(event *TableEvent) {
+	h.mu.RLock()
+	subscriptions := make([]*tableEventSubscription, 0, len(h.subscriptions))
+	for _, s := range h.subscriptions {
+		subscriptions = append(subscriptions, s)
+	}
+	h.mu.RUnlock()
+
+	for _, s := range subscriptions {
+		s.deliver(event)
+	}
+}
+
+func (h *tableEventHub) close() {
+	h.mu.Lock()
+	subscriptions := h.subscriptions
+	h.subscriptions = make(map[string]*tableEventSubscription)
+	h.mu.Unlock()
+
+	for _, s := range subscriptions {
+		s.close()
+	}
+}
@@ -0,0 +1,183 @@
+package pwbtable
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrTableStoreNotFound       = errors.New("table store: table not found")
+	ErrTableStoreInvalidTableID = errors.New("table store: invalid table id")
+)
+
+// TableStore keeps the latest snapshot of tables, including the game state, for crash recovery.
+type TableStore interface {
+	SaveTable(table *Table) error
+	LoadTable(tableID string) (*Table, error)
+	DeleteTable(tableID string) error
+	TableIDs() ([]string, error)
+}
+
+type memoryTableStore struct {
+	mu     sync.RWMutex
+	tables map[string][]byte
+}
+
+func NewMemoryTableStore() TableStore {
+	return &memoryTableStore{
+		tables: make(map[string][]byte),
+	}
+}
+
+func (s *memoryTableStore) SaveTable(table *Table) error {
+	encoded, err := json.Marshal(table)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tables[table.ID] = encoded
+	return nil
+}
+
+func (s *memoryTableStore) LoadTable(tableID string) (*Table, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	encoded, exist := s.tables[tableID]
+	if !exist {
+		return nil, ErrTableStoreNotFound
+	}
+
+	return decodeTable(encoded)
+}
+
+func (s *memoryTableStore) DeleteTable(tableID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tables, tableID)
+	return nil
+}
+
+func (s *memoryTableStore) TableIDs() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tableIDs := make([]string, 0, len(s.tables))
+	for tableID := range s.tables {
+		tableIDs = append(tableIDs, tableID)
+	}
+	return tableIDs, nil
+}
+
+type fileTableStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileTableStore keeps every table as a JSON file in dir.
+func NewFileTableStore(dir string) (TableStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &fileTableStore{
+		dir: dir,
+	}, nil
+}
+
+func (s *fileTableStore) SaveTable(table *Table) error {
+	path, err := s.path(table.ID)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(table)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// write to temp file first so that a crash never leaves a partial snapshot
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, encoded, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func (s *fileTableStore) LoadTable(tableID string) (*Table, error) {
+	path, err := s.path(tableID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	encoded, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrTableStoreNotFound
+		}
+		return nil, err
+	}
+
+	return decodeTable(encoded)
+}
+
+func (s *fileTableStore) DeleteTable(tableID string) error {
+	path, err := s.path(tableID)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *fileTableStore) TableIDs() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	tableIDs := make([]string, 0)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		tableIDs = append(tableIDs, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	return tableIDs, nil
+}
+
+func (s *fileTableStore) path(tableID string) (string, error) {
+	if tableID == "" || tableID != filepath.Base(tableID) || strings.HasPrefix(tableID, ".") {
+		return "", ErrTableStoreInvalidTableID
+	}
+	return filepath.Join(s.dir, tableID+".json"), nil
+}
+
+func decodeTable(encoded []byte) (*Table, error) {
+	var table Table
+	if err := json.Unmarshal(encoded, &table); err != nil {
+		return nil, err
+	}
+	return &table, nil
+}
@@ -0,0 +1,70 @@
+package pwbtable
+
+import (
+	"github.com/weedbox/pokerface"
+)
+
+// ViewFor returns a copy of table with hole cards of other players, the deck and burned cards removed.
+// Hole cards are only revealed at showdown, where winners and all-in players show and others muck.
+func (t Table) ViewFor(playerID string) (*Table, error) {
+	view, err := t.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	gs := view.State.GameState
+	if gs == nil {
+		return view, nil
+	}
+
+	gs.Meta.Deck = make([]string, 0)
+	gs.Status.Burned = make([]string, 0)
+
+	viewerIdx := view.FindGamePlayerIdx(playerID)
+	for _, player := range gs.Players {
+		if player.Idx == viewerIdx || isHoleCardsShown(gs, player) {
+			continue
+		}
+
+		player.HoleCards = make([]string, 0)
+		player.Combination = nil
+	}
+
+	return view, nil
+}
+
+// SpectatorView returns a copy of table for those who are not seated.
+func (t Table) SpectatorView() (*Table, error) {
+	return t.ViewFor("")
+}
+
+func isHoleCardsShown(gs *pokerface.GameState, player *pokerface.PlayerState) bool {
+	if gs.Result == nil || player.Fold {
+		return false
+	}
+
+	// uncontested pot is won without showdown
+	contenders := 0
+	for _, p := range gs.Players {
+		if !p.Fold {
+			contenders++
+		}
+	}
+	if contenders < 2 {
+		return false
+	}
+
+	if player.StackSize == 0 {
+		return true
+	}
+
+	for _, pot := range gs.Result.Pots {
+		for _, winner := range pot.Winners {
+			if winner.Idx == player.Idx {
+				return true
+			}
+		}
+	}
+
+	return false
+}
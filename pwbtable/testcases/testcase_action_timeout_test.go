@@ -0,0 +1,116 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+	"github.com/weedbox/pokerface"
+)
+
+func TestTableGame_Preflop_ActionTimeout(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// given conditions
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck"}
+	redeemChips := int64(15000)
+	players := funk.Map(playerIDs, func(playerID string) pwbtable.JoinPlayer {
+		return pwbtable.JoinPlayer{
+			PlayerID:    playerID,
+			RedeemChips: redeemChips,
+			Seat:        -1,
+		}
+	}).([]pwbtable.JoinPlayer)
+
+	// create manager & table
+	var tableEngine pwbtable.TableEngine
+	manager := pwbtable.NewManager()
+	tableEngineOption := pwbtable.NewTableEngineOptions()
+	tableEngineOption.Interval = 3
+	tableEngineCallbacks := pwbtable.NewTableEngineCallbacks()
+	tableEngineCallbacks.OnTableUpdated = func(table *pwbtable.Table) {
+		switch table.State.Status {
+		case pwbtable.TableStateStatus_TableGameOpened:
+			DebugPrintTableGameOpened(*table)
+		case pwbtable.TableStateStatus_TableGamePlaying:
+			t.Logf("[%s] %s:", table.State.GameState.Status.Round, table.State.GameState.Status.CurrentEvent)
+			event, ok := pokerface.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok {
+				return
+			}
+
+			switch event {
+			case pokerface.GameEvent_ReadyRequested:
+				for _, playerID := range playerIDs {
+					assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+				}
+			case pokerface.GameEvent_AnteRequested:
+				for _, playerID := range playerIDs {
+					assert.Nil(t, tableEngine.PlayerPay(playerID, table.State.BlindState.Ante), fmt.Sprintf("%s pay ante error", playerID))
+				}
+			case pokerface.GameEvent_BlindsRequested:
+				blind := table.State.BlindState
+
+				sbPlayerID := findPlayerID(table, "sb")
+				assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+
+				bbPlayerID := findPlayerID(table, "bb")
+				assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+			case pokerface.GameEvent_RoundStarted:
+				// nobody moves, every player should be timed out
+				assert.NotEqual(t, int64(pwbtable.UnsetValue), table.State.ActionEndAt, "action deadline is not set")
+			}
+		case pwbtable.TableStateStatus_TableGameSettled:
+			// dealer & sb are folded by timeout, bb wins the blinds
+			assert.NotNil(t, table.State.GameState.Result, "invalid game result")
+			assert.Equal(t, 1, table.State.GameCount)
+			assert.Equal(t, int64(pwbtable.UnsetValue), table.State.ActionEndAt, "action deadline is not cleared")
+			for _, playerResult := range table.State.GameState.Result.Players {
+				playerIdx := table.State.GamePlayerIndexes[playerResult.Idx]
+				player := table.State.PlayerStates[playerIdx]
+				assert.Equal(t, playerResult.Final, player.Bankroll)
+
+				if funk.Contains(player.Positions, "bb") {
+					assert.Equal(t, redeemChips+table.State.BlindState.SB, player.Bankroll)
+				}
+			}
+
+			DebugPrintTableGameSettled(*table)
+
+			if table.State.GameState.Status.CurrentEvent == pokerface.GameEventSymbols[pokerface.GameEvent_GameClosed] {
+				wg.Done()
+				return
+			}
+		}
+	}
+	tableEngineCallbacks.OnTableErrorUpdated = func(table *pwbtable.Table, err error) {
+		t.Log("[Table] Error:", err)
+	}
+	tableSetting := NewDefaultTableSetting()
+	tableSetting.Meta.ActionTime = 1
+	table, err := manager.CreateTable(tableEngineOption, tableEngineCallbacks, tableSetting)
+	assert.Nil(t, err, "create table failed")
+
+	// get table engine
+	tableEngine, err = manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	// players buy in
+	for _, joinPlayer := range players {
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", joinPlayer.PlayerID))
+		assert.Nil(t, tableEngine.PlayerJoin(joinPlayer.PlayerID), fmt.Sprintf("%s join error", joinPlayer.PlayerID))
+	}
+
+	// start game
+	tableEngine.UpdateBlind(1, 0, 0, 10, 20)
+	assert.Nil(t, tableEngine.StartTableGame(), "start table game failed")
+
+	wg.Wait()
+
+	// stop the next game
+	assert.Nil(t, manager.CloseTable(table.ID), "close table failed")
+}
@@ -0,0 +1,148 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+	"github.com/weedbox/pokerface"
+)
+
+func TestTableAutoMode_Toggle(t *testing.T) {
+	// given conditions
+	tableSetting := NewDefaultTableSetting(pwbtable.JoinPlayer{PlayerID: "Fred", RedeemChips: 1000, Seat: pwbtable.UnsetValue})
+
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	table, err := manager.CreateTable(nil, nil, tableSetting)
+	assert.Nil(t, err, "create table failed")
+
+	tableEngine, err := manager.GetTableEngine(table.ID)
+	assert.Nil(t, err)
+
+	assert.Nil(t, manager.PlayerAutoMode(table.ID, "Fred", true))
+	table = tableEngine.GetTable()
+	assert.True(t, table.State.PlayerStates[table.FindPlayerIdx("Fred")].IsAutoMode)
+
+	// nothing changes
+	assert.Nil(t, manager.PlayerAutoMode(table.ID, "Fred", true))
+
+	assert.Nil(t, manager.PlayerAutoMode(table.ID, "Fred", false))
+	table = tableEngine.GetTable()
+	assert.False(t, table.State.PlayerStates[table.FindPlayerIdx("Fred")].IsAutoMode)
+
+	assert.ErrorIs(t, manager.PlayerAutoMode(table.ID, "Chuck", true), pwbtable.ErrTablePlayerNotFound)
+	assert.ErrorIs(t, manager.PlayerAutoMode("unknown", "Fred", true), pwbtable.ErrManagerTableNotFound)
+}
+
+type countingAutoModeStrategy struct {
+	moves int32
+}
+
+func (s *countingAutoModeStrategy) RequestMove(te pwbtable.TableEngine, table *pwbtable.Table, playerID string) error {
+	atomic.AddInt32(&s.moves, 1)
+	return pwbtable.NewCheckOrFoldStrategy().RequestMove(te, table, playerID)
+}
+
+func TestTableAutoMode_PlayHand(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// given conditions
+	players := []pwbtable.JoinPlayer{
+		{PlayerID: "Fred", RedeemChips: 1000, Seat: pwbtable.UnsetValue},
+		{PlayerID: "Jeffrey", RedeemChips: 1000, Seat: pwbtable.UnsetValue},
+	}
+
+	// create manager & table
+	var tableEngine pwbtable.TableEngine
+	strategy := &countingAutoModeStrategy{}
+	manager := pwbtable.NewManager()
+	tableEngineOption := pwbtable.NewTableEngineOptions()
+	tableEngineOption.Interval = 3
+	tableEngineOption.AutoModeStrategy = strategy
+	tableEngineCallbacks := pwbtable.NewTableEngineCallbacks()
+	tableEngineCallbacks.OnTableUpdated = func(table *pwbtable.Table) {
+		switch table.State.Status {
+		case pwbtable.TableStateStatus_TableGamePlaying:
+			// Fred is played by table engine, Jeffrey calls or checks to the showdown
+			gs := table.State.GameState
+			gamePlayerIdx := table.FindGamePlayerIdx("Jeffrey")
+			event, ok := pokerface.GameEventBySymbol[gs.Status.CurrentEvent]
+			if !ok || gamePlayerIdx == pwbtable.UnsetValue {
+				return
+			}
+
+			switch event {
+			case pokerface.GameEvent_ReadyRequested:
+				if gs.HasAction(gamePlayerIdx, pwbtable.Action_Ready) {
+					assert.Nil(t, tableEngine.PlayerReady("Jeffrey"), "Jeffrey ready error")
+				}
+			case pokerface.GameEvent_BlindsRequested:
+				if !gs.HasAction(gamePlayerIdx, pwbtable.Action_Pay) {
+					return
+				}
+
+				blind := table.State.BlindState
+				chips := blind.SB
+				if findPlayerID(table, "bb") == "Jeffrey" {
+					chips = blind.BB
+				}
+				assert.Nil(t, tableEngine.PlayerPay("Jeffrey", chips), "Jeffrey pay error")
+			case pokerface.GameEvent_RoundStarted:
+				playerID, actions := currentPlayerMove(table)
+				if playerID != "Jeffrey" {
+					return
+				}
+
+				if funk.Contains(actions, "call") {
+					assert.Nil(t, tableEngine.PlayerCall(playerID), fmt.Sprintf("%s call error", playerID))
+				} else {
+					assert.Nil(t, tableEngine.PlayerCheck(playerID), fmt.Sprintf("%s check error", playerID))
+				}
+			}
+		case pwbtable.TableStateStatus_TableGameSettled:
+			if table.State.GameState.Status.CurrentEvent == pokerface.GameEventSymbols[pokerface.GameEvent_GameClosed] {
+				wg.Done()
+				return
+			}
+		}
+	}
+	tableEngineCallbacks.OnTableErrorUpdated = func(table *pwbtable.Table, err error) {
+		t.Log("[Table] Error:", err)
+	}
+	table, err := manager.CreateTable(tableEngineOption, tableEngineCallbacks, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	// get table engine
+	tableEngine, err = manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	// players buy in
+	for _, joinPlayer := range players {
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", joinPlayer.PlayerID))
+		assert.Nil(t, tableEngine.PlayerJoin(joinPlayer.PlayerID), fmt.Sprintf("%s join error", joinPlayer.PlayerID))
+	}
+	assert.Nil(t, tableEngine.PlayerAutoMode("Fred", true), "Fred auto mode error")
+
+	// start game
+	tableEngine.UpdateBlind(1, 0, 0, 10, 20)
+	assert.Nil(t, tableEngine.StartTableGame(), "start table game failed")
+
+	wg.Wait()
+
+	// Fred is readied and pays blind by table engine, every wager move of Fred is made by the strategy
+	table = tableEngine.GetTable()
+	fred := table.State.PlayerStates[table.FindPlayerIdx("Fred")]
+	assert.Greater(t, atomic.LoadInt32(&strategy.moves), int32(0), "auto mode strategy is not used")
+	assert.Equal(t, int(atomic.LoadInt32(&strategy.moves)), fred.GameStatistics.ActionTimes)
+	assert.Equal(t, 0, fred.GameStatistics.CallTimes+fred.GameStatistics.RaiseTimes)
+
+	// stop the next game
+	assert.Nil(t, manager.CloseTable(table.ID), "close table failed")
+}
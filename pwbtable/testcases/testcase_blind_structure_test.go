@@ -0,0 +1,134 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+	"github.com/weedbox/pokerface"
+)
+
+func TestTableGame_BlindStructure_LevelByHands(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// given conditions
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck"}
+	redeemChips := int64(15000)
+	tableSetting := NewDefaultTableSetting()
+	tableSetting.Meta.BlindStructure = pwbtable.TableBlindStructure{
+		Levels: []pwbtable.TableBlindLevel{
+			{Level: 1, SB: 10, BB: 20, Hands: 1},
+			{Level: 2, SB: 20, BB: 40, Hands: 1},
+		},
+	}
+
+	// create manager & table
+	var tableEngine pwbtable.TableEngine
+	var closeOnce sync.Once
+	manager := pwbtable.NewManager()
+	tableEngineOption := pwbtable.NewTableEngineOptions()
+	tableEngineOption.Interval = 3
+	tableEngineCallbacks := pwbtable.NewTableEngineCallbacks()
+	tableEngineCallbacks.OnTableUpdated = func(table *pwbtable.Table) {
+		switch table.State.Status {
+		case pwbtable.TableStateStatus_TableGamePlaying:
+			event, ok := pokerface.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok {
+				return
+			}
+
+			switch event {
+			case pokerface.GameEvent_ReadyRequested:
+				for _, playerID := range playerIDs {
+					assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+				}
+			case pokerface.GameEvent_BlindsRequested:
+				blind := table.State.BlindState
+				sbPlayerID := findPlayerID(table, "sb")
+				assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+				bbPlayerID := findPlayerID(table, "bb")
+				assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+			case pokerface.GameEvent_RoundStarted:
+				playerID, actions := currentPlayerMove(table)
+				if funk.Contains(actions, "fold") {
+					assert.Nil(t, tableEngine.PlayerFold(playerID), fmt.Sprintf("%s fold error", playerID))
+				}
+			}
+		case pwbtable.TableStateStatus_TableGameSettled:
+			if table.State.GameState.Status.CurrentEvent != pokerface.GameEventSymbols[pokerface.GameEvent_GameClosed] {
+				return
+			}
+
+			switch table.State.GameCount {
+			case 1:
+				assert.Equal(t, int64(20), table.State.BlindState.BB)
+			case 2:
+				assert.Equal(t, int64(40), table.State.BlindState.BB)
+				closeOnce.Do(wg.Done)
+			}
+		}
+	}
+	tableEngineCallbacks.OnTableErrorUpdated = func(table *pwbtable.Table, err error) {
+		t.Log("[Table] Error:", err)
+	}
+	table, err := manager.CreateTable(tableEngineOption, tableEngineCallbacks, tableSetting)
+	assert.Nil(t, err, "create table failed")
+
+	tableEngine, err = manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	levels := make([]pwbtable.TableBlindState, 0)
+	var levelsLock sync.Mutex
+	s := tableEngine.SubscribeTableEventsFunc(nil, func(event *pwbtable.TableEvent) {
+		if event.Kind != pwbtable.TableEventKind_BlindLevelUpdated {
+			return
+		}
+
+		levelsLock.Lock()
+		defer levelsLock.Unlock()
+		levels = append(levels, event.Payload.(pwbtable.TableBlindState))
+	})
+
+	// players buy in
+	for _, playerID := range playerIDs {
+		joinPlayer := pwbtable.JoinPlayer{PlayerID: playerID, RedeemChips: redeemChips, Seat: -1}
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", playerID))
+		assert.Nil(t, tableEngine.PlayerJoin(playerID), fmt.Sprintf("%s join error", playerID))
+	}
+
+	// start game without UpdateBlind, blinds come from blind structure
+	assert.Nil(t, tableEngine.StartTableGame(), "start table game failed")
+
+	wg.Wait()
+	assert.Nil(t, tableEngine.CloseTable(), "close table failed")
+	s.Unsubscribe()
+
+	levelsLock.Lock()
+	defer levelsLock.Unlock()
+	if assert.Len(t, levels, 2) {
+		assert.Equal(t, 1, levels[0].Level)
+		assert.Equal(t, 1, levels[0].NextLevelGameCount)
+		assert.Equal(t, 2, levels[1].Level)
+		assert.Equal(t, pwbtable.UnsetValue, levels[1].NextLevelGameCount)
+	}
+}
+
+func TestTableGame_BlindStructure_Invalid(t *testing.T) {
+	manager := pwbtable.NewManager()
+
+	tableSetting := NewDefaultTableSetting()
+	tableSetting.Meta.BlindStructure = pwbtable.TableBlindStructure{
+		Levels: []pwbtable.TableBlindLevel{
+			{Level: 1, SB: 10, BB: 20, Duration: 60},
+			{IsBreak: true},
+			{Level: 2, SB: 20, BB: 40},
+		},
+	}
+
+	_, err := manager.CreateTable(pwbtable.NewTableEngineOptions(), pwbtable.NewTableEngineCallbacks(), tableSetting)
+	assert.Equal(t, pwbtable.ErrTableInvalidBlindStructure, err)
+}
@@ -0,0 +1,282 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+	"github.com/weedbox/pokerface"
+)
+
+func TestTableGame_Callbacks_Sequence(t *testing.T) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	wg.Add(1)
+
+	// given conditions
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck"}
+	redeemChips := int64(15000)
+	players := funk.Map(playerIDs, func(playerID string) pwbtable.JoinPlayer {
+		return pwbtable.JoinPlayer{
+			PlayerID:    playerID,
+			RedeemChips: redeemChips,
+			Seat:        -1,
+		}
+	}).([]pwbtable.JoinPlayer)
+
+	// collected callbacks
+	tableStatuses := make([]pwbtable.TableStateStatus, 0)
+	reservedPlayerIDs := make([]string, 0)
+	playerStateUpdates := make(map[string]int)
+	gameActions := make([]pwbtable.TablePlayerGameAction, 0)
+	expectedActionPlayerIDs := make([]string, 0)
+
+	// create manager & table
+	var tableEngine pwbtable.TableEngine
+	manager := pwbtable.NewManager()
+	tableEngineOption := pwbtable.NewTableEngineOptions()
+	tableEngineOption.Interval = 3
+	tableEngineCallbacks := pwbtable.NewTableEngineCallbacks()
+	tableEngineCallbacks.OnTableStateUpdated = func(event string, table *pwbtable.Table) {
+		mu.Lock()
+		defer mu.Unlock()
+		tableStatuses = append(tableStatuses, table.State.Status)
+	}
+	tableEngineCallbacks.OnTablePlayerReserved = func(competitionID, tableID string, playerState *pwbtable.TablePlayerState) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, redeemChips, playerState.Bankroll)
+		reservedPlayerIDs = append(reservedPlayerIDs, playerState.PlayerID)
+	}
+	tableEngineCallbacks.OnTablePlayerStateUpdated = func(competitionID, tableID string, playerState *pwbtable.TablePlayerState) {
+		mu.Lock()
+		defer mu.Unlock()
+		playerStateUpdates[playerState.PlayerID]++
+	}
+	tableEngineCallbacks.OnGamePlayerActionUpdated = func(gameAction pwbtable.TablePlayerGameAction) {
+		mu.Lock()
+		defer mu.Unlock()
+		gameActions = append(gameActions, gameAction)
+	}
+	tableEngineCallbacks.OnTableUpdated = func(table *pwbtable.Table) {
+		switch table.State.Status {
+		case pwbtable.TableStateStatus_TableGamePlaying:
+			event, ok := pokerface.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok {
+				return
+			}
+
+			switch event {
+			case pokerface.GameEvent_ReadyRequested:
+				for _, playerID := range playerIDs {
+					assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+				}
+			case pokerface.GameEvent_BlindsRequested:
+				blind := table.State.BlindState
+
+				sbPlayerID := findPlayerID(table, "sb")
+				bbPlayerID := findPlayerID(table, "bb")
+				mu.Lock()
+				expectedActionPlayerIDs = append(expectedActionPlayerIDs, sbPlayerID, bbPlayerID)
+				mu.Unlock()
+
+				assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+				assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+			case pokerface.GameEvent_RoundStarted:
+				playerID, actions := currentPlayerMove(table)
+				if funk.Contains(actions, "fold") {
+					mu.Lock()
+					expectedActionPlayerIDs = append(expectedActionPlayerIDs, playerID)
+					mu.Unlock()
+
+					assert.Nil(t, tableEngine.PlayerFold(playerID), fmt.Sprintf("%s fold error", playerID))
+				}
+			}
+		case pwbtable.TableStateStatus_TableGameSettled:
+			if table.State.GameState.Status.CurrentEvent == pokerface.GameEventSymbols[pokerface.GameEvent_GameClosed] {
+				wg.Done()
+				return
+			}
+		}
+	}
+	tableEngineCallbacks.OnTableErrorUpdated = func(table *pwbtable.Table, err error) {
+		t.Log("[Table] Error:", err)
+	}
+	table, err := manager.CreateTable(tableEngineOption, tableEngineCallbacks, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	// get table engine
+	tableEngine, err = manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	// players buy in
+	for _, joinPlayer := range players {
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", joinPlayer.PlayerID))
+		assert.Nil(t, tableEngine.PlayerJoin(joinPlayer.PlayerID), fmt.Sprintf("%s join error", joinPlayer.PlayerID))
+	}
+
+	// start game
+	tableEngine.UpdateBlind(1, 0, 0, 10, 20)
+	assert.Nil(t, tableEngine.StartTableGame(), "start table game failed")
+
+	wg.Wait()
+
+	// stop the next game
+	assert.Nil(t, manager.CloseTable(table.ID), "close table failed")
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// reserved & player states
+	assert.Equal(t, playerIDs, reservedPlayerIDs)
+	for _, playerID := range playerIDs {
+		// seated, joined and at least one bankroll update after settlement for blind players
+		assert.GreaterOrEqual(t, playerStateUpdates[playerID], 2, fmt.Sprintf("%s player state updates", playerID))
+	}
+
+	// table status transitions
+	expectedStatuses := []pwbtable.TableStateStatus{
+		pwbtable.TableStateStatus_TableCreated,
+		pwbtable.TableStateStatus_TableGameOpened,
+		pwbtable.TableStateStatus_TableGamePlaying,
+		pwbtable.TableStateStatus_TableGameSettled,
+	}
+	assert.Equal(t, expectedStatuses, tableStatuses[:len(expectedStatuses)])
+
+	// game actions: sb pay, bb pay, dealer fold, sb fold
+	assert.Equal(t, []string{"pay", "pay", "fold", "fold"}, funk.Map(gameActions, func(gameAction pwbtable.TablePlayerGameAction) string {
+		return gameAction.Action
+	}))
+	assert.Equal(t, expectedActionPlayerIDs, funk.Map(gameActions, func(gameAction pwbtable.TablePlayerGameAction) string {
+		return gameAction.PlayerID
+	}))
+	for _, gameAction := range gameActions {
+		assert.Equal(t, table.ID, gameAction.TableID)
+		assert.Equal(t, 1, gameAction.GameCount)
+		assert.NotEmpty(t, gameAction.Positions)
+		if gameAction.Action == "fold" {
+			assert.Equal(t, "preflop", gameAction.Round)
+		}
+	}
+}
+
+func TestTableGame_Callbacks_ClosingCallRound(t *testing.T) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	wg.Add(1)
+
+	// given conditions
+	playerIDs := []string{"Fred", "Jeffrey"}
+	redeemChips := int64(15000)
+	players := funk.Map(playerIDs, func(playerID string) pwbtable.JoinPlayer {
+		return pwbtable.JoinPlayer{
+			PlayerID:    playerID,
+			RedeemChips: redeemChips,
+			Seat:        -1,
+		}
+	}).([]pwbtable.JoinPlayer)
+
+	// collected callbacks
+	gameActions := make([]pwbtable.TablePlayerGameAction, 0)
+	isRaised := false
+
+	// create manager & table
+	var tableEngine pwbtable.TableEngine
+	manager := pwbtable.NewManager()
+	tableEngineOption := pwbtable.NewTableEngineOptions()
+	tableEngineOption.Interval = 3
+	tableEngineCallbacks := pwbtable.NewTableEngineCallbacks()
+	tableEngineCallbacks.OnGamePlayerActionUpdated = func(gameAction pwbtable.TablePlayerGameAction) {
+		mu.Lock()
+		defer mu.Unlock()
+		gameActions = append(gameActions, gameAction)
+	}
+	tableEngineCallbacks.OnTableUpdated = func(table *pwbtable.Table) {
+		switch table.State.Status {
+		case pwbtable.TableStateStatus_TableGamePlaying:
+			event, ok := pokerface.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok {
+				return
+			}
+
+			switch event {
+			case pokerface.GameEvent_ReadyRequested:
+				for _, playerID := range playerIDs {
+					assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+				}
+			case pokerface.GameEvent_BlindsRequested:
+				blind := table.State.BlindState
+
+				sbPlayerID := findPlayerID(table, "sb")
+				assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+
+				bbPlayerID := findPlayerID(table, "bb")
+				assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+			case pokerface.GameEvent_RoundStarted:
+				// sb raises, bb calls to close preflop, then bb folds on the flop
+				playerID, actions := currentPlayerMove(table)
+				if table.State.GameState.Status.Round != "preflop" {
+					assert.Nil(t, tableEngine.PlayerFold(playerID), fmt.Sprintf("%s fold error", playerID))
+					return
+				}
+
+				mu.Lock()
+				shouldRaise := !isRaised && funk.Contains(actions, "raise")
+				isRaised = true
+				mu.Unlock()
+
+				if shouldRaise {
+					assert.Nil(t, tableEngine.PlayerRaise(playerID, 40), fmt.Sprintf("%s raise error", playerID))
+				} else if funk.Contains(actions, "call") {
+					assert.Nil(t, tableEngine.PlayerCall(playerID), fmt.Sprintf("%s call error", playerID))
+				}
+			}
+		case pwbtable.TableStateStatus_TableGameSettled:
+			if table.State.GameState.Status.CurrentEvent == pokerface.GameEventSymbols[pokerface.GameEvent_GameClosed] {
+				wg.Done()
+				return
+			}
+		}
+	}
+	tableEngineCallbacks.OnTableErrorUpdated = func(table *pwbtable.Table, err error) {
+		t.Log("[Table] Error:", err)
+	}
+	table, err := manager.CreateTable(tableEngineOption, tableEngineCallbacks, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	// get table engine
+	tableEngine, err = manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	// players buy in
+	for _, joinPlayer := range players {
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", joinPlayer.PlayerID))
+		assert.Nil(t, tableEngine.PlayerJoin(joinPlayer.PlayerID), fmt.Sprintf("%s join error", joinPlayer.PlayerID))
+	}
+
+	// start game
+	tableEngine.UpdateBlind(1, 0, 0, 10, 20)
+	assert.Nil(t, tableEngine.StartTableGame(), "start table game failed")
+
+	wg.Wait()
+
+	// stop the next game
+	assert.Nil(t, manager.CloseTable(table.ID), "close table failed")
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// game actions: sb pay, bb pay, sb raise, bb call, bb fold
+	assert.Equal(t, []string{"pay", "pay", "raise", "call", "fold"}, funk.Map(gameActions, func(gameAction pwbtable.TablePlayerGameAction) string {
+		return gameAction.Action
+	}))
+
+	// the call closing preflop belongs to preflop even though the game moves on to the flop
+	callAction := gameActions[3]
+	assert.Equal(t, "preflop", callAction.Round)
+	assert.Equal(t, int64(20), callAction.Chips)
+	assert.Equal(t, "flop", gameActions[4].Round)
+}
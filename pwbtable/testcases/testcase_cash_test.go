@@ -0,0 +1,71 @@
+package testcases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+)
+
+func TestTableCash_BuyInLimits(t *testing.T) {
+	// given conditions
+	tableSetting := NewDefaultTableSetting()
+	tableSetting.Meta.Cash = pwbtable.TableCashSetting{
+		MinBuyIn:      40,
+		MaxBuyIn:      100,
+		IsInBB:        true,
+		RatHolingTime: 60,
+	}
+
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	table, err := manager.CreateTable(nil, nil, tableSetting)
+	assert.Nil(t, err, "create table failed")
+	assert.Nil(t, manager.UpdateBlind(table.ID, 1, 0, 0, 10, 20))
+
+	buyIn := func(playerID string, chips int64) error {
+		return manager.PlayerReserve(table.ID, pwbtable.JoinPlayer{PlayerID: playerID, RedeemChips: chips, Seat: pwbtable.UnsetValue})
+	}
+
+	// first buy-in: 800 ~ 2000 chips
+	assert.ErrorIs(t, buyIn("Fred", 0), pwbtable.ErrTableInvalidBuyIn)
+	assert.ErrorIs(t, buyIn("Fred", 500), pwbtable.ErrTableBuyInTooSmall)
+	assert.ErrorIs(t, buyIn("Fred", 3000), pwbtable.ErrTableBuyInTooLarge)
+	assert.Nil(t, buyIn("Fred", 1000))
+
+	// top-up is capped by max buy-in
+	assert.Nil(t, buyIn("Fred", 500))
+	assert.ErrorIs(t, manager.PlayerRedeemChips(table.ID, pwbtable.JoinPlayer{PlayerID: "Fred", RedeemChips: 600}), pwbtable.ErrTableBuyInTooLarge)
+	assert.Nil(t, manager.PlayerRedeemChips(table.ID, pwbtable.JoinPlayer{PlayerID: "Fred", RedeemChips: 500}))
+
+	// player who leaves with 2000 chips brings them back
+	assert.Nil(t, manager.PlayersLeave(table.ID, []string{"Fred"}))
+	assert.ErrorIs(t, buyIn("Fred", 1000), pwbtable.ErrTableBuyInTooSmall)
+	assert.Nil(t, buyIn("Fred", 2000))
+
+	tableEngine, err := manager.GetTableEngine(table.ID)
+	assert.Nil(t, err)
+	table = tableEngine.GetTable()
+	assert.Equal(t, int64(2000), table.State.PlayerStates[table.FindPlayerIdx("Fred")].Bankroll)
+
+	// busted player buys in at least min buy-in again
+	table.State.PlayerStates[table.FindPlayerIdx("Fred")].Bankroll = 0
+	assert.ErrorIs(t, buyIn("Fred", 500), pwbtable.ErrTableBuyInTooSmall)
+	assert.ErrorIs(t, manager.PlayerRedeemChips(table.ID, pwbtable.JoinPlayer{PlayerID: "Fred", RedeemChips: 500}), pwbtable.ErrTableBuyInTooSmall)
+	assert.Nil(t, buyIn("Fred", 800))
+	assert.Equal(t, int64(800), table.State.PlayerStates[table.FindPlayerIdx("Fred")].Bankroll)
+}
+
+func TestTableCash_InvalidSetting(t *testing.T) {
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	tableSetting := NewDefaultTableSetting()
+	tableSetting.Meta.Cash = pwbtable.TableCashSetting{
+		MinBuyIn: 2000,
+		MaxBuyIn: 1000,
+	}
+	_, err := manager.CreateTable(nil, nil, tableSetting)
+	assert.ErrorIs(t, err, pwbtable.ErrTableInvalidCreateSetting)
+}
@@ -0,0 +1,117 @@
+package testcases
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+	"github.com/weedbox/pokerface"
+)
+
+func TestTableGame_HandHistory_Preflop_Walk(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// given conditions
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck"}
+	redeemChips := int64(15000)
+
+	// create table engine with hand history recorder
+	tableEngineOption := pwbtable.NewTableEngineOptions()
+	tableEngineOption.Interval = 3
+	tableEngine := pwbtable.NewTableEngine(
+		tableEngineOption,
+		pwbtable.WithGameBackend(pwbtable.NewNativeGameBackend()),
+		pwbtable.WithHandHistoryRecorder(pwbtable.NewHandHistoryRecorder(10)),
+	)
+	tableEngine.OnTableUpdated(func(table *pwbtable.Table) {
+		switch table.State.Status {
+		case pwbtable.TableStateStatus_TableGamePlaying:
+			event, ok := pokerface.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok {
+				return
+			}
+
+			switch event {
+			case pokerface.GameEvent_ReadyRequested:
+				for _, playerID := range playerIDs {
+					assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+				}
+			case pokerface.GameEvent_BlindsRequested:
+				blind := table.State.BlindState
+				sbPlayerID := findPlayerID(table, "sb")
+				assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+				bbPlayerID := findPlayerID(table, "bb")
+				assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+			case pokerface.GameEvent_RoundStarted:
+				playerID, actions := currentPlayerMove(table)
+				if funk.Contains(actions, "fold") {
+					assert.Nil(t, tableEngine.PlayerFold(playerID), fmt.Sprintf("%s fold error", playerID))
+				}
+			}
+		case pwbtable.TableStateStatus_TableGameSettled:
+			if table.State.GameState.Status.CurrentEvent != pokerface.GameEventSymbols[pokerface.GameEvent_GameClosed] {
+				return
+			}
+			defer wg.Done()
+
+			hh, err := tableEngine.HandHistory(table.State.GameState.GameID)
+			if !assert.Nil(t, err, "hand history not found") {
+				return
+			}
+
+			// structured record
+			bbPlayerID := findPlayerID(table, "bb")
+			assert.Len(t, hh.Seats, len(playerIDs))
+			for _, seat := range hh.Seats {
+				assert.Equal(t, redeemChips, seat.Stack)
+				assert.NotEmpty(t, seat.HoleCards)
+			}
+			assert.Equal(t, pwbtable.GameRound_Preflop, hh.Rounds[0].Round)
+			folds := funk.Filter(hh.Rounds[0].Actions, func(action *pwbtable.HandHistoryAction) bool {
+				return action.Action == pwbtable.WagerAction_Fold
+			}).([]*pwbtable.HandHistoryAction)
+			assert.Len(t, folds, 2)
+			assert.Equal(t, bbPlayerID, hh.Pots[0].Winners[0].PlayerID)
+
+			data, err := hh.GetJSON()
+			assert.Nil(t, err)
+			var decoded pwbtable.HandHistory
+			assert.Nil(t, json.Unmarshal([]byte(data), &decoded))
+			assert.Equal(t, hh.GameID, decoded.GameID)
+
+			// PokerStars text
+			text := hh.GetPokerStarsText()
+			t.Log("\n" + text)
+			assert.True(t, strings.HasPrefix(text, "PokerStars Hand #"))
+			assert.Contains(t, text, "*** HOLE CARDS ***")
+			assert.Contains(t, text, fmt.Sprintf("%s: posts big blind 20", bbPlayerID))
+			assert.Contains(t, text, fmt.Sprintf("%s collected 30 from pot", bbPlayerID))
+			assert.Contains(t, text, "folded before Flop")
+		}
+	})
+
+	_, err := tableEngine.CreateTable(NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	// players buy in
+	for _, playerID := range playerIDs {
+		joinPlayer := pwbtable.JoinPlayer{PlayerID: playerID, RedeemChips: redeemChips, Seat: -1}
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", playerID))
+		assert.Nil(t, tableEngine.PlayerJoin(playerID), fmt.Sprintf("%s join error", playerID))
+	}
+
+	// start game
+	tableEngine.UpdateBlind(1, 0, 0, 10, 20)
+	assert.Nil(t, tableEngine.StartTableGame(), "start table game failed")
+
+	wg.Wait()
+
+	// stop the next game
+	assert.Nil(t, tableEngine.CloseTable(), "close table failed")
+}
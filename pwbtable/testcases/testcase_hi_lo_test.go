@@ -0,0 +1,116 @@
+package testcases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/pot"
+	"github.com/weedbox/pokerface/settlement"
+)
+
+func newHiLoGameState(board []string, holeCards [][]string, total int64) *pokerface.GameState {
+	players := make([]*pokerface.PlayerState, 0)
+	contributors := make(map[int]int64)
+	resultPlayers := make([]*settlement.PlayerResult, 0)
+	for idx, cards := range holeCards {
+		players = append(players, &pokerface.PlayerState{Idx: idx, HoleCards: cards})
+		contributors[idx] = total / int64(len(holeCards))
+		resultPlayers = append(resultPlayers, &settlement.PlayerResult{Idx: idx, Final: 1000 - total/int64(len(holeCards)), Changed: -total / int64(len(holeCards))})
+	}
+
+	// player 1 wins high hand
+	resultPlayers[1].Final += total
+	resultPlayers[1].Changed += total
+
+	return &pokerface.GameState{
+		Status: pokerface.Status{
+			Board: board,
+			Pots:  []*pot.Pot{{Total: total, Contributors: contributors}},
+		},
+		Players: players,
+		Result: &settlement.Result{
+			Players: resultPlayers,
+			Pots: []*settlement.PotResult{
+				{Total: total, Winners: []*settlement.Winner{{Idx: 1, Withdraw: total}}},
+			},
+		},
+	}
+}
+
+func TestHiLo_BestLowHand(t *testing.T) {
+	board := []string{"SA", "H2", "D7", "CK", "SQ"}
+
+	// exactly two hole cards are used
+	ranks, cards := pwbtable.BestLowHand([]string{"C3", "D4", "H5", "DK"}, board)
+	assert.Equal(t, []int{7, 4, 3, 2, 1}, ranks)
+	assert.ElementsMatch(t, []string{"C3", "D4", "SA", "H2", "D7"}, cards)
+
+	// paired rank does not make a low
+	ranks, _ = pwbtable.BestLowHand([]string{"CA", "D2", "HK", "DK"}, board)
+	assert.Nil(t, ranks)
+}
+
+func TestHiLo_SplitAndScoop(t *testing.T) {
+	// player 0 wins low half, odd chip goes to high half
+	gs := newHiLoGameState(
+		[]string{"SA", "H2", "D7", "CK", "SQ"},
+		[][]string{{"C3", "D4", "HK", "DK"}, {"HA", "DA", "CQ", "DQ"}, {"C9", "D9", "HT", "DT"}},
+		301,
+	)
+	splitPots, err := pwbtable.SplitHiLoResult(gs)
+	assert.Nil(t, err)
+	assert.Equal(t, []pwbtable.TableSplitPot{
+		{PotIdx: 0, Half: pwbtable.PotHalf_High},
+		{PotIdx: 0, Half: pwbtable.PotHalf_Low, Cards: []string{"C3", "D4", "SA", "H2", "D7"}},
+	}, splitPots)
+	assert.Equal(t, int64(151), gs.Result.Pots[0].Winners[0].Withdraw)
+	assert.Equal(t, int64(150), gs.Result.Pots[1].Winners[0].Withdraw)
+	assert.Equal(t, int64(1050), gs.Result.Players[0].Final)
+	assert.Equal(t, int64(50), gs.Result.Players[0].Changed)
+	assert.Equal(t, int64(1051), gs.Result.Players[1].Final)
+
+	// no qualifying low, high hand scoops
+	gs = newHiLoGameState(
+		[]string{"SK", "HQ", "D9", "CT", "S2"},
+		[][]string{{"C3", "D4", "HK", "DK"}, {"HA", "DA", "CQ", "DQ"}, {"C9", "D9", "HT", "DT"}},
+		300,
+	)
+	splitPots, err = pwbtable.SplitHiLoResult(gs)
+	assert.Nil(t, err)
+	assert.Equal(t, []pwbtable.TableSplitPot{{PotIdx: 0, Half: pwbtable.PotHalf_High}}, splitPots)
+	assert.Equal(t, int64(1200), gs.Result.Players[1].Final)
+}
+
+func TestHiLo_Quartering(t *testing.T) {
+	// player 0 and 2 share low half
+	gs := newHiLoGameState(
+		[]string{"SA", "H2", "D7", "CK", "SQ"},
+		[][]string{{"C3", "D4", "HK", "DK"}, {"HA", "DA", "CQ", "DQ"}, {"H3", "S4", "C9", "D9"}},
+		300,
+	)
+	_, err := pwbtable.SplitHiLoResult(gs)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(gs.Result.Pots[1].Winners))
+	assert.Equal(t, int64(75), gs.Result.Pots[1].Winners[0].Withdraw)
+	assert.Equal(t, int64(975), gs.Result.Players[0].Final)
+	assert.Equal(t, int64(975), gs.Result.Players[2].Final)
+	assert.Equal(t, int64(1050), gs.Result.Players[1].Final)
+}
+
+func TestHiLo_PotMismatch(t *testing.T) {
+	gs := newHiLoGameState(
+		[]string{"SA", "H2", "D7", "CK", "SQ"},
+		[][]string{{"C3", "D4", "HK", "DK"}, {"HA", "DA", "CQ", "DQ"}, {"C9", "D9", "HT", "DT"}},
+		300,
+	)
+	gs.Result.Pots = append(gs.Result.Pots, gs.Result.Pots[0])
+
+	// high hand keeps the result
+	splitPots, err := pwbtable.SplitHiLoResult(gs)
+	assert.ErrorIs(t, err, pwbtable.ErrTableHiLoPotMismatch)
+	assert.Nil(t, splitPots)
+	assert.Equal(t, 2, len(gs.Result.Pots))
+	assert.Equal(t, int64(1200), gs.Result.Players[1].Final)
+}
@@ -0,0 +1,148 @@
+package testcases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+	"github.com/weedbox/pokerface"
+)
+
+func TestBettingLimit_DefaultByRule(t *testing.T) {
+	meta := pwbtable.TableMeta{Rule: pwbtable.CompetitionRule_Omaha}
+	assert.Equal(t, pwbtable.BettingLimit_PotLimit, meta.BettingLimit())
+
+	meta.Limit = pwbtable.BettingLimit_NoLimit
+	assert.Equal(t, pwbtable.BettingLimit_NoLimit, meta.BettingLimit())
+
+	meta = pwbtable.TableMeta{Rule: pwbtable.CompetitionRule_Default}
+	assert.Equal(t, pwbtable.BettingLimit_NoLimit, meta.BettingLimit())
+}
+
+func TestBettingLimit_InvalidSetting(t *testing.T) {
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	tableSetting := NewDefaultTableSetting()
+	tableSetting.Meta.Limit = "unknown"
+	_, err := manager.CreateTable(nil, nil, tableSetting)
+	assert.ErrorIs(t, err, pwbtable.ErrTableInvalidCreateSetting)
+
+	tableSetting.Meta.Limit = pwbtable.BettingLimit_FixedLimit
+	tableSetting.Meta.FixedLimit.RaiseCap = -1
+	_, err = manager.CreateTable(nil, nil, tableSetting)
+	assert.ErrorIs(t, err, pwbtable.ErrTableInvalidCreateSetting)
+}
+
+func TestBettingLimit_PotLimitChipLevel(t *testing.T) {
+	// blinds 10/20, UTG calls 20 then raises the pot of 50
+	gs := &pokerface.GameState{
+		Status: pokerface.Status{CurrentWager: 20},
+		Players: []*pokerface.PlayerState{
+			{Idx: 0, Pot: 0, Wager: 0, StackSize: 1000},
+			{Idx: 1, Pot: 10, Wager: 10, StackSize: 990},
+			{Idx: 2, Pot: 20, Wager: 20, StackSize: 980},
+		},
+	}
+	assert.Equal(t, int64(70), pwbtable.PotLimitChipLevel(gs, 0))
+
+	// small blind completes 10 then raises the pot of 40
+	assert.Equal(t, int64(60), pwbtable.PotLimitChipLevel(gs, 1))
+}
+
+func TestBettingLimit_FixedLimitBetSize(t *testing.T) {
+	table := pwbtable.Table{
+		Meta: pwbtable.TableMeta{Limit: pwbtable.BettingLimit_FixedLimit},
+		State: &pwbtable.TableState{
+			BlindState: &pwbtable.TableBlindState{SB: 10, BB: 20},
+		},
+	}
+
+	// follow big blind by default
+	assert.Equal(t, int64(20), table.FixedLimitBetSize(pwbtable.GameRound_Preflop))
+	assert.Equal(t, int64(20), table.FixedLimitBetSize(pwbtable.GameRound_Flop))
+	assert.Equal(t, int64(40), table.FixedLimitBetSize(pwbtable.GameRound_Turn))
+	assert.Equal(t, int64(40), table.FixedLimitBetSize(pwbtable.GameRound_River))
+
+	table.State.BlindState.SmallBet = 30
+	table.State.BlindState.BigBet = 60
+	assert.Equal(t, int64(30), table.FixedLimitBetSize(pwbtable.GameRound_Flop))
+	assert.Equal(t, int64(60), table.FixedLimitBetSize(pwbtable.GameRound_River))
+}
+
+func TestBettingLimit_FixedLimitRaiseCap(t *testing.T) {
+	table := pwbtable.Table{
+		Meta: pwbtable.TableMeta{Limit: pwbtable.BettingLimit_FixedLimit},
+		State: &pwbtable.TableState{
+			BlindState: &pwbtable.TableBlindState{SB: 10, BB: 20},
+		},
+	}
+
+	gs := &pokerface.GameState{
+		GameID: "game",
+		Status: pokerface.Status{Round: pwbtable.GameRound_Flop, CurrentWager: 60},
+		Players: []*pokerface.PlayerState{
+			{Idx: 0},
+			{Idx: 1},
+			{Idx: 2, Fold: true},
+		},
+	}
+
+	// a bet and two raises
+	table.State.FixedLimit = pwbtable.TableFixedLimitState{GameID: "game", Round: pwbtable.GameRound_Flop, Raises: 3, ChipLevel: 60}
+	assert.False(t, table.IsRaiseCapped(gs))
+
+	// a bet and three raises
+	gs.Status.CurrentWager = 80
+	table.State.FixedLimit = pwbtable.TableFixedLimitState{GameID: "game", Round: pwbtable.GameRound_Flop, Raises: 4, ChipLevel: 80}
+	assert.True(t, table.IsRaiseCapped(gs))
+
+	table.Meta.FixedLimit.IsHeadsUpUncapped = true
+	assert.False(t, table.IsRaiseCapped(gs))
+
+	gs.Players[2].Fold = false
+	assert.True(t, table.IsRaiseCapped(gs))
+
+	// raises of another round are not counted
+	gs.Status.Round = pwbtable.GameRound_Turn
+	assert.False(t, table.IsRaiseCapped(gs))
+}
+
+func TestBettingLimit_FixedLimitShortAllin(t *testing.T) {
+	table := pwbtable.Table{
+		Meta: pwbtable.TableMeta{Limit: pwbtable.BettingLimit_FixedLimit},
+		State: &pwbtable.TableState{
+			BlindState: &pwbtable.TableBlindState{SB: 10, BB: 20},
+		},
+	}
+
+	// a bet and two raises, then a short all-in raises to 70
+	gs := &pokerface.GameState{
+		GameID: "game",
+		Status: pokerface.Status{Round: pwbtable.GameRound_Flop, CurrentWager: 70},
+		Players: []*pokerface.PlayerState{
+			{Idx: 0},
+			{Idx: 1},
+			{Idx: 2},
+		},
+	}
+	table.State.FixedLimit = pwbtable.TableFixedLimitState{GameID: "game", Round: pwbtable.GameRound_Flop, Raises: 3, ChipLevel: 60}
+
+	// the incomplete raise neither caps the round nor changes the raise size
+	assert.False(t, table.IsRaiseCapped(gs))
+	assert.Equal(t, int64(80), table.FixedLimitChipLevel(gs))
+
+	// nobody raises preflop yet, big blind counts as the first bet
+	gs = &pokerface.GameState{
+		GameID: "game",
+		Meta:   pokerface.Meta{Blind: pokerface.BlindSetting{SB: 10, BB: 20}},
+		Status: pokerface.Status{Round: pwbtable.GameRound_Preflop, CurrentWager: 20},
+		Players: []*pokerface.PlayerState{
+			{Idx: 0},
+			{Idx: 1},
+			{Idx: 2},
+		},
+	}
+	assert.False(t, table.IsRaiseCapped(gs))
+	assert.Equal(t, int64(40), table.FixedLimitChipLevel(gs))
+}
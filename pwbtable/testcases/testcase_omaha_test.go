@@ -0,0 +1,48 @@
+package testcases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+)
+
+func TestOmaha_HoleCardsCount(t *testing.T) {
+	assert.Equal(t, 2, pwbtable.HoleCardsCount(pwbtable.CompetitionRule_Default))
+	assert.Equal(t, 4, pwbtable.HoleCardsCount(pwbtable.CompetitionRule_Omaha))
+	assert.Equal(t, 5, pwbtable.HoleCardsCount(pwbtable.CompetitionRule_Omaha5))
+	assert.Equal(t, 6, pwbtable.HoleCardsCount(pwbtable.CompetitionRule_Omaha6))
+
+	meta := pwbtable.TableMeta{Rule: pwbtable.CompetitionRule_Omaha6}
+	assert.Equal(t, pwbtable.BettingLimit_PotLimit, meta.BettingLimit())
+}
+
+func TestOmaha_SeatCountLimitedByDeck(t *testing.T) {
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	cases := []struct {
+		rule      string
+		seatCount int
+		isValid   bool
+	}{
+		{pwbtable.CompetitionRule_Omaha, 10, true},
+		{pwbtable.CompetitionRule_Omaha5, 8, true},
+		{pwbtable.CompetitionRule_Omaha5, 9, false},
+		{pwbtable.CompetitionRule_Omaha6, 7, true},
+		{pwbtable.CompetitionRule_Omaha6, 8, false},
+	}
+
+	for _, c := range cases {
+		tableSetting := NewDefaultTableSetting()
+		tableSetting.Meta.Rule = c.rule
+		tableSetting.Meta.TableMaxSeatCount = c.seatCount
+
+		_, err := manager.CreateTable(nil, nil, tableSetting)
+		if c.isValid {
+			assert.Nil(t, err, "%s with %d seats", c.rule, c.seatCount)
+		} else {
+			assert.ErrorIs(t, err, pwbtable.ErrTableInvalidCreateSetting, "%s with %d seats", c.rule, c.seatCount)
+		}
+	}
+}
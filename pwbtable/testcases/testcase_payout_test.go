@@ -0,0 +1,124 @@
+package testcases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+)
+
+func TestPayout_Ladder(t *testing.T) {
+	payout := pwbtable.PayoutSetting{
+		BuyIn:        100,
+		Fee:          10,
+		EntrantCount: 9,
+		RebuyCount:   2,
+		RebuyPrice:   100,
+		AddOnCount:   1,
+		AddOnPrice:   50,
+		MinChipUnit:  25,
+		Structure: pwbtable.PayoutStructure{
+			Type:        pwbtable.PayoutStructureType_Ladder,
+			Percentages: []float64{50, 30, 20},
+		},
+	}
+
+	assert.Equal(t, int64(1150), payout.PrizePool())
+	assert.Equal(t, int64(90), payout.TotalFee())
+
+	// 575, 345 and 230 are rounded down to chip unit, the rest goes to the winner
+	prizes, err := payout.Prizes()
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{600, 325, 225}, prizes)
+}
+
+func TestPayout_LadderMoreThanEntrants(t *testing.T) {
+	payout := pwbtable.PayoutSetting{
+		BuyIn:        100,
+		EntrantCount: 2,
+		Structure: pwbtable.PayoutStructure{
+			Type:        pwbtable.PayoutStructureType_Ladder,
+			Percentages: []float64{50, 30, 20},
+		},
+	}
+
+	// 3rd place is never reached, its prize goes to the paid places
+	assert.Equal(t, []float64{62.5, 37.5}, payout.Percentages())
+	prizes, err := payout.Prizes()
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{125, 75}, prizes)
+
+	// 131 and 78 are rounded down, the rest of 10 is less than chip unit
+	payout.BuyIn = 105
+	payout.MinChipUnit = 25
+	prizes, err = payout.Prizes()
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{125, 75}, prizes)
+}
+
+func TestPayout_Formula(t *testing.T) {
+	payout := pwbtable.PayoutSetting{
+		BuyIn:        100,
+		EntrantCount: 20,
+		MinChipUnit:  10,
+		Structure: pwbtable.PayoutStructure{
+			Type:      pwbtable.PayoutStructureType_Formula,
+			PaidRatio: 0.15,
+			Decay:     0.5,
+		},
+	}
+
+	prizes, err := payout.Prizes()
+	assert.Nil(t, err)
+	if assert.Len(t, prizes, 3) {
+		total := int64(0)
+		for idx, prize := range prizes {
+			assert.Equal(t, int64(0), prize%10)
+			if idx > 0 {
+				assert.Less(t, prize, prizes[idx-1])
+			}
+			total += prize
+		}
+		assert.Equal(t, payout.PrizePool(), total)
+	}
+}
+
+func TestPayout_InvalidSetting(t *testing.T) {
+	payout := pwbtable.PayoutSetting{
+		BuyIn:        100,
+		EntrantCount: 9,
+		Structure: pwbtable.PayoutStructure{
+			Type:        pwbtable.PayoutStructureType_Ladder,
+			Percentages: []float64{80, 30},
+		},
+	}
+
+	_, err := payout.Prizes()
+	assert.Equal(t, pwbtable.ErrPayoutInvalidSetting, err)
+}
+
+func TestPayout_SimultaneousBusts(t *testing.T) {
+	// Fred, Jeffrey and Chuck are busted in the same hand while 2 players are left
+	places := pwbtable.RankBustedPlayers([]pwbtable.PayoutBustedPlayer{
+		{PlayerID: "Fred", StartingStack: 300},
+		{PlayerID: "Jeffrey", StartingStack: 800},
+		{PlayerID: "Chuck", StartingStack: 300},
+	}, 5)
+
+	ranks := make(map[string]int)
+	for _, place := range places {
+		ranks[place.PlayerID] = place.Place
+	}
+	assert.Equal(t, 3, ranks["Jeffrey"])
+	assert.Equal(t, 4, ranks["Fred"])
+	assert.Equal(t, 4, ranks["Chuck"])
+
+	// Fred and Chuck split 4th and 5th places, odd chips go to the first of them
+	places = append(places, &pwbtable.PayoutPlace{PlayerID: "Loz", Place: 1}, &pwbtable.PayoutPlace{PlayerID: "Kimi", Place: 2})
+	prizes := pwbtable.SplitPrizes([]int64{500, 300, 200, 150, 100}, places, 10)
+	assert.Equal(t, int64(500), prizes["Loz"])
+	assert.Equal(t, int64(300), prizes["Kimi"])
+	assert.Equal(t, int64(200), prizes["Jeffrey"])
+	assert.Equal(t, int64(130), prizes["Fred"])
+	assert.Equal(t, int64(120), prizes["Chuck"])
+}
@@ -0,0 +1,83 @@
+package testcases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+	"github.com/weedbox/pokerface/settlement"
+)
+
+func TestRake_SplitPot(t *testing.T) {
+	// player 0 & 1 split 1010 chips, player 2 loses
+	result := &settlement.Result{
+		Players: []*settlement.PlayerResult{
+			{Idx: 0, Final: 1505, Changed: 505},
+			{Idx: 1, Final: 1505, Changed: 505},
+			{Idx: 2, Final: 0, Changed: -1010},
+		},
+		Pots: []*settlement.PotResult{
+			{Total: 3010, Winners: []*settlement.Winner{{Idx: 0, Withdraw: 1505}, {Idx: 1, Withdraw: 1505}}},
+		},
+	}
+
+	setting := pwbtable.TableRakeSetting{Percentage: 5}
+	potRakes := setting.ApplyRake(result, true, 1)
+
+	// 150 chips = 75 + 75
+	assert.Equal(t, []int64{150}, potRakes)
+	assert.Equal(t, int64(1430), result.Players[0].Final)
+	assert.Equal(t, int64(430), result.Players[0].Changed)
+	assert.Equal(t, int64(1430), result.Players[1].Final)
+	assert.Equal(t, int64(0), result.Players[2].Final)
+	assert.Equal(t, int64(1430), result.Pots[0].Winners[0].Withdraw)
+	assert.Equal(t, int64(1430), result.Pots[0].Winners[1].Withdraw)
+}
+
+func TestRake_SidePotsWithCap(t *testing.T) {
+	// player 0 wins main pot, player 1 wins side pot
+	result := &settlement.Result{
+		Players: []*settlement.PlayerResult{
+			{Idx: 0, Final: 1500, Changed: 1000},
+			{Idx: 1, Final: 1000, Changed: 0},
+			{Idx: 2, Final: 0, Changed: -1000},
+		},
+		Pots: []*settlement.PotResult{
+			{Total: 1500, Winners: []*settlement.Winner{{Idx: 0, Withdraw: 1500}}},
+			{Total: 1000, Winners: []*settlement.Winner{{Idx: 1, Withdraw: 1000}}},
+		},
+	}
+
+	setting := pwbtable.TableRakeSetting{
+		Percentage: 10,
+		Caps: []pwbtable.TableRakeCap{
+			{MinPlayerCount: 2, Cap: 100},
+			{MinPlayerCount: 3, Cap: 200},
+		},
+	}
+	assert.Equal(t, int64(100), setting.Cap(2))
+	assert.Equal(t, int64(200), setting.Cap(6))
+
+	// main pot takes 150, side pot takes the rest of cap
+	potRakes := setting.ApplyRake(result, true, 10)
+	assert.Equal(t, []int64{150, 50}, potRakes)
+	assert.Equal(t, int64(1350), result.Players[0].Final)
+	assert.Equal(t, int64(950), result.Players[1].Final)
+	assert.Equal(t, int64(-50), result.Players[1].Changed)
+}
+
+func TestRake_NoFlopNoDrop(t *testing.T) {
+	result := &settlement.Result{
+		Players: []*settlement.PlayerResult{
+			{Idx: 0, Final: 1100, Changed: 100},
+			{Idx: 1, Final: 900, Changed: -100},
+		},
+		Pots: []*settlement.PotResult{
+			{Total: 200, Winners: []*settlement.Winner{{Idx: 0, Withdraw: 200}}},
+		},
+	}
+
+	setting := pwbtable.TableRakeSetting{Percentage: 5, IsNoFlopNoDrop: true}
+	assert.Equal(t, []int64{0}, setting.ApplyRake(result, false, 1))
+	assert.Equal(t, int64(1100), result.Players[0].Final)
+}
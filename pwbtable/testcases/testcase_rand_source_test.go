@@ -0,0 +1,131 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+	"github.com/weedbox/pokerface"
+)
+
+func TestTableGame_RandSource_Deterministic(t *testing.T) {
+	deck := pwbtable.NewDeckCards(pwbtable.CompetitionRule_Default)
+
+	first := playSeededWalkGame(t, 42, deck)
+	second := playSeededWalkGame(t, 42, deck)
+
+	assert.Equal(t, first.State.SeatMap, second.State.SeatMap)
+	assert.Equal(t, first.State.CurrentDealerSeat, second.State.CurrentDealerSeat)
+	assert.Equal(t, deck, first.State.GameState.Meta.Deck)
+	for idx, player := range first.State.GameState.Players {
+		assert.Equal(t, player.HoleCards, second.State.GameState.Players[idx].HoleCards)
+	}
+}
+
+func playSeededWalkGame(t *testing.T, seed int64, deck []string) *pwbtable.Table {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// given conditions
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck"}
+	redeemChips := int64(15000)
+	var result *pwbtable.Table
+
+	// create table engine with seeded rand source and fixed deck
+	tableEngineOption := pwbtable.NewTableEngineOptions()
+	tableEngineOption.Interval = 3
+	tableEngine := pwbtable.NewTableEngine(
+		tableEngineOption,
+		pwbtable.WithGameBackend(pwbtable.NewNativeGameBackend()),
+		pwbtable.WithRandSource(pwbtable.NewSeededRandSource(seed)),
+		pwbtable.WithDeckProvider(pwbtable.NewFixedDeckProvider(deck)),
+	)
+	tableEngine.OnTableUpdated(func(table *pwbtable.Table) {
+		switch table.State.Status {
+		case pwbtable.TableStateStatus_TableGamePlaying:
+			event, ok := pokerface.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok {
+				return
+			}
+
+			switch event {
+			case pokerface.GameEvent_ReadyRequested:
+				for _, playerID := range playerIDs {
+					assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+				}
+			case pokerface.GameEvent_BlindsRequested:
+				blind := table.State.BlindState
+				sbPlayerID := findPlayerID(table, "sb")
+				assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+				bbPlayerID := findPlayerID(table, "bb")
+				assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+			case pokerface.GameEvent_RoundStarted:
+				playerID, actions := currentPlayerMove(table)
+				if funk.Contains(actions, "fold") {
+					assert.Nil(t, tableEngine.PlayerFold(playerID), fmt.Sprintf("%s fold error", playerID))
+				}
+			}
+		case pwbtable.TableStateStatus_TableGameSettled:
+			if table.State.GameState.Status.CurrentEvent != pokerface.GameEventSymbols[pokerface.GameEvent_GameClosed] {
+				return
+			}
+			if result == nil {
+				result, _ = table.Clone()
+				wg.Done()
+			}
+		}
+	})
+
+	_, err := tableEngine.CreateTable(NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	// players buy in with random seats
+	for _, playerID := range playerIDs {
+		joinPlayer := pwbtable.JoinPlayer{PlayerID: playerID, RedeemChips: redeemChips, Seat: -1}
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", playerID))
+		assert.Nil(t, tableEngine.PlayerJoin(playerID), fmt.Sprintf("%s join error", playerID))
+	}
+
+	// start game
+	tableEngine.UpdateBlind(1, 0, 0, 10, 20)
+	assert.Nil(t, tableEngine.StartTableGame(), "start table game failed")
+
+	wg.Wait()
+
+	// stop the next game
+	assert.Nil(t, tableEngine.CloseTable(), "close table failed")
+
+	return result
+}
+
+func TestTableDeck_SeededShuffle(t *testing.T) {
+	// deck is built in the same order every time
+	assert.Equal(t, pwbtable.NewDeckCards(pwbtable.CompetitionRule_Default), pwbtable.NewDeckCards(pwbtable.CompetitionRule_Default))
+	assert.Len(t, pwbtable.NewDeckCards(pwbtable.CompetitionRule_ShortDeck), 36)
+
+	first := pwbtable.NewShuffledDeckProvider(pwbtable.NewSeededRandSource(42)).NewDeck(pwbtable.CompetitionRule_Default)
+	second := pwbtable.NewShuffledDeckProvider(pwbtable.NewSeededRandSource(42)).NewDeck(pwbtable.CompetitionRule_Default)
+	assert.Equal(t, first, second)
+	assert.ElementsMatch(t, pwbtable.NewDeckCards(pwbtable.CompetitionRule_Default), first)
+}
+
+func TestTableSeats_RandSource(t *testing.T) {
+	seatMap := pwbtable.NewDefaultSeatMap(9)
+
+	first, err := pwbtable.RandomSeatsWithSource(pwbtable.NewSeededRandSource(42), seatMap, 3)
+	assert.Nil(t, err)
+	second, err := pwbtable.RandomSeatsWithSource(pwbtable.NewSeededRandSource(42), seatMap, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, first, second)
+
+	// default rand source
+	seats, err := pwbtable.RandomSeats(seatMap, 3)
+	assert.Nil(t, err)
+	assert.Len(t, seats, 3)
+
+	_, err = pwbtable.RandomSeats(seatMap, 10)
+	assert.ErrorIs(t, err, pwbtable.ErrTableNoEmptySeats)
+}
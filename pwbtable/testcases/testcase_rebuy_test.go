@@ -0,0 +1,125 @@
+package testcases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+)
+
+func TestTableRebuy_Limits(t *testing.T) {
+	// given conditions
+	tableSetting := NewDefaultTableSetting(
+		pwbtable.JoinPlayer{PlayerID: "Jeffrey", RedeemChips: 500, Seat: pwbtable.UnsetValue},
+		pwbtable.JoinPlayer{PlayerID: "Chuck", RedeemChips: 0, Seat: pwbtable.UnsetValue},
+	)
+	tableSetting.Meta.Mode = pwbtable.CompetitionMode_MTT
+	tableSetting.Meta.ReBuy = pwbtable.TableReBuySetting{
+		MaxCount:     2,
+		MaxStackSize: 1000,
+	}
+	tableSetting.Meta.AddOn = pwbtable.TableAddOnSetting{
+		MaxCount: 1,
+	}
+
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	table, err := manager.CreateTable(nil, nil, tableSetting)
+	assert.Nil(t, err, "create table failed")
+
+	rebuy := func(playerID string, chips int64) error {
+		return manager.PlayerReserve(table.ID, pwbtable.JoinPlayer{PlayerID: playerID, RedeemChips: chips, Seat: pwbtable.UnsetValue})
+	}
+
+	// stack threshold
+	assert.Nil(t, rebuy("Jeffrey", 1000))
+	assert.ErrorIs(t, rebuy("Jeffrey", 1000), pwbtable.ErrTablePlayerStackTooLarge)
+
+	// rebuy count
+	assert.Nil(t, rebuy("Chuck", 1000))
+	assert.Nil(t, manager.PlayerRedeemChips(table.ID, pwbtable.JoinPlayer{PlayerID: "Chuck", RedeemChips: 1000}))
+	assert.ErrorIs(t, rebuy("Chuck", 1000), pwbtable.ErrTableBuyInLimitReached)
+
+	// add-on is only offered at break
+	err = manager.PlayerAddOn(table.ID, pwbtable.JoinPlayer{PlayerID: "Chuck", RedeemChips: 1000})
+	assert.ErrorIs(t, err, pwbtable.ErrTableBuyInPeriodEnded)
+
+	tableEngine, err := manager.GetTableEngine(table.ID)
+	assert.Nil(t, err)
+	table = tableEngine.GetTable()
+	assert.Equal(t, int64(1500), table.State.PlayerStates[table.FindPlayerIdx("Jeffrey")].Bankroll)
+	assert.Equal(t, int64(2000), table.State.PlayerStates[table.FindPlayerIdx("Chuck")].Bankroll)
+	assert.Equal(t, 1, table.FindPlayerEntry("Jeffrey").ReBuyCount)
+	assert.Equal(t, 2, table.FindPlayerEntry("Chuck").ReBuyCount)
+}
+
+func TestTableRebuy_Disabled(t *testing.T) {
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	// tournament without rebuy setting
+	tableSetting := NewDefaultTableSetting(pwbtable.JoinPlayer{PlayerID: "Fred", RedeemChips: 0, Seat: pwbtable.UnsetValue})
+	tableSetting.Meta.Mode = pwbtable.CompetitionMode_MTT
+	table, err := manager.CreateTable(nil, nil, tableSetting)
+	assert.Nil(t, err, "create table failed")
+	assert.ErrorIs(t, manager.PlayerReserve(table.ID, pwbtable.JoinPlayer{PlayerID: "Fred", RedeemChips: 1000, Seat: pwbtable.UnsetValue}), pwbtable.ErrTableReBuyNotAllowed)
+	assert.ErrorIs(t, manager.PlayerAddOn(table.ID, pwbtable.JoinPlayer{PlayerID: "Fred", RedeemChips: 1000}), pwbtable.ErrTableAddOnNotAllowed)
+
+	// cash game has no limits
+	tableSetting = NewDefaultTableSetting(pwbtable.JoinPlayer{PlayerID: "Fred", RedeemChips: 1000, Seat: pwbtable.UnsetValue})
+	table, err = manager.CreateTable(nil, nil, tableSetting)
+	assert.Nil(t, err, "create table failed")
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, manager.PlayerReserve(table.ID, pwbtable.JoinPlayer{PlayerID: "Fred", RedeemChips: 1000, Seat: pwbtable.UnsetValue}))
+	}
+
+	// sit & go is played by fixed entrants
+	tableSetting = NewDefaultTableSetting()
+	tableSetting.Meta.Mode = pwbtable.CompetitionMode_SitAndGo
+	tableSetting.Meta.BlindStructure = pwbtable.TableBlindStructure{
+		Levels: []pwbtable.TableBlindLevel{{Level: 1, SB: 50, BB: 100}},
+	}
+	tableSetting.Meta.ReEntry = pwbtable.TableReEntrySetting{MaxCount: 1}
+	_, err = manager.CreateTable(nil, nil, tableSetting)
+	assert.ErrorIs(t, err, pwbtable.ErrTableInvalidCreateSetting)
+}
+
+func TestTableRebuy_CarriedEntry(t *testing.T) {
+	// given conditions
+	tableSetting := NewDefaultTableSetting(pwbtable.JoinPlayer{PlayerID: "Jeffrey", RedeemChips: 1500, Seat: pwbtable.UnsetValue})
+	tableSetting.Meta.Mode = pwbtable.CompetitionMode_MTT
+	tableSetting.Meta.ReBuy = pwbtable.TableReBuySetting{
+		MaxCount:     1,
+		MaxStackSize: 1000,
+	}
+
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	table, err := manager.CreateTable(nil, nil, tableSetting)
+	assert.Nil(t, err, "create table failed")
+
+	tableEngine, err := manager.GetTableEngine(table.ID)
+	assert.Nil(t, err)
+
+	// failed validation adds no entry
+	assert.ErrorIs(t, manager.PlayerReserve(table.ID, pwbtable.JoinPlayer{PlayerID: "Jeffrey", RedeemChips: 1000, Seat: pwbtable.UnsetValue}), pwbtable.ErrTablePlayerStackTooLarge)
+	assert.Nil(t, tableEngine.GetTable().FindPlayerEntry("Jeffrey"))
+
+	// player moved from another table keeps the counts
+	assert.Nil(t, manager.PlayerReserve(table.ID, pwbtable.JoinPlayer{
+		PlayerID:    "Chuck",
+		RedeemChips: 0,
+		Seat:        pwbtable.UnsetValue,
+		Entry:       &pwbtable.TablePlayerEntry{ReBuyCount: 1, AddOnCount: 1},
+	}))
+	assert.ErrorIs(t, manager.PlayerReserve(table.ID, pwbtable.JoinPlayer{PlayerID: "Chuck", RedeemChips: 1000, Seat: pwbtable.UnsetValue}), pwbtable.ErrTableBuyInLimitReached)
+
+	entry := tableEngine.GetTable().FindPlayerEntry("Chuck")
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, "Chuck", entry.PlayerID)
+		assert.Equal(t, 1, entry.ReBuyCount)
+		assert.Equal(t, 1, entry.AddOnCount)
+	}
+}
@@ -0,0 +1,135 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+	"github.com/weedbox/pokerface"
+)
+
+func TestTableGame_Replay_Preflop_Walk(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// given conditions
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck"}
+	redeemChips := int64(15000)
+	gameID := ""
+
+	// create table engine with recording game backend
+	backend := pwbtable.NewRecordingGameBackend(pwbtable.NewNativeGameBackend(), 0)
+	tableEngineOption := pwbtable.NewTableEngineOptions()
+	tableEngineOption.Interval = 3
+	tableEngine := pwbtable.NewTableEngine(tableEngineOption, pwbtable.WithGameBackend(backend))
+	tableEngine.OnTableUpdated(func(table *pwbtable.Table) {
+		switch table.State.Status {
+		case pwbtable.TableStateStatus_TableGamePlaying:
+			event, ok := pokerface.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok {
+				return
+			}
+
+			switch event {
+			case pokerface.GameEvent_ReadyRequested:
+				for _, playerID := range playerIDs {
+					assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+				}
+			case pokerface.GameEvent_BlindsRequested:
+				blind := table.State.BlindState
+				sbPlayerID := findPlayerID(table, "sb")
+				assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+				bbPlayerID := findPlayerID(table, "bb")
+				assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+			case pokerface.GameEvent_RoundStarted:
+				playerID, actions := currentPlayerMove(table)
+				if funk.Contains(actions, "fold") {
+					assert.Nil(t, tableEngine.PlayerFold(playerID), fmt.Sprintf("%s fold error", playerID))
+				}
+			}
+		case pwbtable.TableStateStatus_TableGameSettled:
+			if table.State.GameState.Status.CurrentEvent != pokerface.GameEventSymbols[pokerface.GameEvent_GameClosed] {
+				return
+			}
+			gameID = table.State.GameState.GameID
+			wg.Done()
+		}
+	})
+
+	_, err := tableEngine.CreateTable(NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	// players buy in
+	for _, playerID := range playerIDs {
+		joinPlayer := pwbtable.JoinPlayer{PlayerID: playerID, RedeemChips: redeemChips, Seat: -1}
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", playerID))
+		assert.Nil(t, tableEngine.PlayerJoin(playerID), fmt.Sprintf("%s join error", playerID))
+	}
+
+	// start game
+	tableEngine.UpdateBlind(1, 0, 0, 10, 20)
+	assert.Nil(t, tableEngine.StartTableGame(), "start table game failed")
+
+	wg.Wait()
+
+	// stop the next game
+	assert.Nil(t, tableEngine.CloseTable(), "close table failed")
+
+	record, err := backend.HandRecord(gameID)
+	if !assert.Nil(t, err, "hand record not found") {
+		return
+	}
+	assert.NotEmpty(t, record.Steps)
+
+	// replay on a fresh backend
+	result, err := pwbtable.ReplayHand(pwbtable.NewNativeGameBackend(), record)
+	assert.Nil(t, err, "replay failed")
+	assert.True(t, result.IsConsistent(), "replay diverged")
+	assert.Equal(t, len(record.Steps), result.ReplayedSteps)
+	assert.NotNil(t, result.FinalState.Result)
+
+	// tampered record
+	tamperedStep := len(record.Steps) / 2
+	record.Steps[tamperedStep].StateHash = "tampered"
+	result, err = pwbtable.ReplayHand(pwbtable.NewNativeGameBackend(), record)
+	assert.Nil(t, err, "replay failed")
+	if assert.NotNil(t, result.Divergence) {
+		assert.Equal(t, tamperedStep, result.Divergence.Step)
+	}
+}
+
+type gameIDBackend struct {
+	pwbtable.GameBackend
+	count int
+}
+
+func (b *gameIDBackend) CreateGame(opts *pokerface.GameOptions) (*pokerface.GameState, error) {
+	b.count++
+	return &pokerface.GameState{GameID: fmt.Sprintf("game-%d", b.count)}, nil
+}
+
+func TestRecordingGameBackend_MaxHands(t *testing.T) {
+	backend := pwbtable.NewRecordingGameBackend(&gameIDBackend{}, 2)
+	for i := 0; i < 3; i++ {
+		_, err := backend.CreateGame(&pokerface.GameOptions{})
+		assert.Nil(t, err)
+	}
+
+	// the oldest record is dropped
+	_, err := backend.HandRecord("game-1")
+	assert.ErrorIs(t, err, pwbtable.ErrReplayHandRecordNotFound)
+	_, err = backend.HandRecord("game-2")
+	assert.Nil(t, err)
+
+	// deleted record leaves room for a new one
+	backend.DeleteHandRecord("game-2")
+	_, err = backend.CreateGame(&pokerface.GameOptions{})
+	assert.Nil(t, err)
+	_, err = backend.HandRecord("game-3")
+	assert.Nil(t, err)
+	_, err = backend.HandRecord("game-4")
+	assert.Nil(t, err)
+}
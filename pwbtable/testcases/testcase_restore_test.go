@@ -0,0 +1,153 @@
+package testcases
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+	"github.com/weedbox/pokerface"
+)
+
+func TestTableGame_Restore_Preflop(t *testing.T) {
+	var crashWG, settleWG sync.WaitGroup
+	crashWG.Add(1)
+	settleWG.Add(1)
+
+	// given conditions
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck"}
+	redeemChips := int64(15000)
+	store := pwbtable.NewMemoryTableStore()
+	tableEngineOption := pwbtable.NewTableEngineOptions()
+	tableEngineOption.Interval = 3
+
+	playGame := func(manager pwbtable.Manager, table *pwbtable.Table, onRoundStarted func()) {
+		event, ok := pokerface.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+		if !ok {
+			return
+		}
+
+		switch event {
+		case pokerface.GameEvent_ReadyRequested:
+			for _, playerID := range playerIDs {
+				assert.Nil(t, manager.PlayerReady(table.ID, playerID), fmt.Sprintf("%s ready error", playerID))
+			}
+		case pokerface.GameEvent_BlindsRequested:
+			blind := table.State.BlindState
+			sbPlayerID := findPlayerID(table, "sb")
+			assert.Nil(t, manager.PlayerPay(table.ID, sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+			bbPlayerID := findPlayerID(table, "bb")
+			assert.Nil(t, manager.PlayerPay(table.ID, bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+		case pokerface.GameEvent_RoundStarted:
+			onRoundStarted()
+		}
+	}
+
+	// first process: crashed when preflop is started
+	var snapshot *pwbtable.Table
+	var crashOnce sync.Once
+	crashedManager := pwbtable.NewManager(pwbtable.WithManagerTableStore(store))
+	crashedCallbacks := pwbtable.NewTableEngineCallbacks()
+	crashedCallbacks.OnTableUpdated = func(table *pwbtable.Table) {
+		if snapshot != nil || table.State.Status != pwbtable.TableStateStatus_TableGamePlaying {
+			return
+		}
+
+		playGame(crashedManager, table, func() {
+			crashOnce.Do(func() {
+				stored, err := store.LoadTable(table.ID)
+				assert.Nil(t, err, "load snapshot failed")
+				assert.Equal(t, table.UpdateSerial, stored.UpdateSerial)
+				snapshot = stored
+				crashWG.Done()
+			})
+		})
+	}
+
+	table, err := crashedManager.CreateTable(tableEngineOption, crashedCallbacks, NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	for _, playerID := range playerIDs {
+		joinPlayer := pwbtable.JoinPlayer{PlayerID: playerID, RedeemChips: redeemChips, Seat: -1}
+		assert.Nil(t, crashedManager.PlayerReserve(table.ID, joinPlayer), fmt.Sprintf("%s reserve error", playerID))
+		assert.Nil(t, crashedManager.PlayerJoin(table.ID, playerID), fmt.Sprintf("%s join error", playerID))
+	}
+
+	assert.Nil(t, crashedManager.UpdateBlind(table.ID, 1, 0, 0, 10, 20), "update blind failed")
+	assert.Nil(t, crashedManager.StartTableGame(table.ID), "start table game failed")
+
+	crashWG.Wait()
+
+	// closed table is removed from store, put the snapshot back as if the process died
+	crashedManager.Reset()
+	_, err = store.LoadTable(table.ID)
+	assert.Equal(t, pwbtable.ErrTableStoreNotFound, err)
+	assert.Nil(t, store.SaveTable(snapshot))
+
+	// second process: restore and finish the hand
+	var settleOnce sync.Once
+	manager := pwbtable.NewManager(pwbtable.WithManagerTableStore(store))
+	callbacks := pwbtable.NewTableEngineCallbacks()
+	callbacks.OnTableUpdated = func(table *pwbtable.Table) {
+		switch table.State.Status {
+		case pwbtable.TableStateStatus_TableGamePlaying:
+			playGame(manager, table, func() {
+				playerID, actions := currentPlayerMove(table)
+				if funk.Contains(actions, "fold") {
+					assert.Nil(t, manager.PlayerFold(table.ID, playerID), fmt.Sprintf("%s fold error", playerID))
+				}
+			})
+		case pwbtable.TableStateStatus_TableGameSettled:
+			settleOnce.Do(func() {
+				assert.Equal(t, snapshot.State.GameState.GameID, table.State.GameState.GameID)
+				assert.Equal(t, 1, table.State.GameCount)
+				assert.NotNil(t, table.State.GameState.Result, "invalid game result")
+				settleWG.Done()
+			})
+		}
+	}
+
+	_, err = manager.RestoreTable(tableEngineOption, callbacks, table.ID)
+	assert.Nil(t, err, "restore table failed")
+
+	settleWG.Wait()
+
+	// stop the next game
+	assert.Nil(t, manager.CloseTable(table.ID), "close table failed")
+}
+
+type failingTableStore struct {
+	pwbtable.TableStore
+}
+
+func (s *failingTableStore) SaveTable(table *pwbtable.Table) error {
+	return errSnapshotUnavailable
+}
+
+var errSnapshotUnavailable = errors.New("snapshot storage unavailable")
+
+func TestTableEngine_Snapshot_SaveFailed(t *testing.T) {
+	store := &failingTableStore{TableStore: pwbtable.NewMemoryTableStore()}
+	tableEngine := pwbtable.NewTableEngine(pwbtable.NewTableEngineOptions(), pwbtable.WithGameBackend(pwbtable.NewNativeGameBackend()), pwbtable.WithTableStore(store))
+
+	var errs []error
+	tableEngine.OnTableErrorUpdated(func(table *pwbtable.Table, err error) {
+		errs = append(errs, err)
+	})
+	sub := tableEngine.SubscribeTableEvents(pwbtable.NewTableEventSubscriptionOptions())
+
+	_, err := tableEngine.CreateTable(NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	// failure is reported instead of being swallowed
+	assert.Equal(t, []error{errSnapshotUnavailable}, errs)
+
+	event := <-sub.Events()
+	assert.Equal(t, pwbtable.TableEventKind_TableCreated, event.Kind)
+	assert.Equal(t, errSnapshotUnavailable.Error(), event.Error)
+
+	sub.Unsubscribe()
+}
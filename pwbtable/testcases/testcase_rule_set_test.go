@@ -0,0 +1,68 @@
+package testcases
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+)
+
+func TestRuleSet_Builtin(t *testing.T) {
+	rs, err := pwbtable.GetRuleSet(pwbtable.CompetitionRule_Omaha)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, rs.HoleCardsCount())
+	assert.Equal(t, 2, rs.RequiredHoleCardsCount())
+	assert.Equal(t, pwbtable.BettingLimit_PotLimit, rs.BettingLimit())
+
+	// empty rule is the default rule
+	rs, err = pwbtable.GetRuleSet("")
+	assert.Nil(t, err)
+	assert.Equal(t, pwbtable.CompetitionRule_Default, rs.Name())
+
+	rs, err = pwbtable.GetRuleSet(pwbtable.CompetitionRule_ShortDeck)
+	assert.Nil(t, err)
+	assert.False(t, rs.IsBetweenDealerBBWaiting())
+	assert.False(t, pwbtable.IsBetweenDealerBB(4, 2, 6, 9, pwbtable.CompetitionRule_ShortDeck))
+	assert.True(t, pwbtable.IsBetweenDealerBB(4, 2, 6, 9, pwbtable.CompetitionRule_Default))
+
+	_, err = pwbtable.GetRuleSet("unknown")
+	assert.ErrorIs(t, err, pwbtable.ErrRuleSetNotFound)
+	assert.ErrorIs(t, pwbtable.RegisterRuleSet(pwbtable.NewRuleSet(pwbtable.CompetitionRule_Default)), pwbtable.ErrRuleSetDuplicated)
+}
+
+func TestRuleSet_Register(t *testing.T) {
+	// three-card hold'em played fixed-limit with own position names
+	name := uuid.New().String()
+	assert.Nil(t, pwbtable.RegisterRuleSet(pwbtable.NewRuleSet(name,
+		pwbtable.WithRuleHoleCards(3, 0),
+		pwbtable.WithRuleBettingLimit(pwbtable.BettingLimit_FixedLimit),
+		pwbtable.WithRulePositions(func(playerCount int) [][]string {
+			positions := make([][]string, 0, playerCount)
+			for i := 0; i < playerCount; i++ {
+				positions = append(positions, []string{pwbtable.Position_Unknown})
+			}
+			return positions
+		}),
+	)))
+
+	assert.Equal(t, 3, pwbtable.HoleCardsCount(name))
+	assert.Equal(t, pwbtable.BettingLimit_FixedLimit, pwbtable.TableMeta{Rule: name}.BettingLimit())
+
+	players := []*pwbtable.TablePlayerState{{PlayerID: "Fred"}, {PlayerID: "Jeffrey"}}
+	positionMap := pwbtable.GetPlayerPositionMap(name, players, []int{0, 1})
+	assert.Equal(t, []string{pwbtable.Position_Unknown}, positionMap[1])
+
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	tableSetting := NewDefaultTableSetting()
+	tableSetting.Meta.Rule = name
+	_, err := manager.CreateTable(nil, nil, tableSetting)
+	assert.Nil(t, err)
+
+	tableSetting = NewDefaultTableSetting()
+	tableSetting.Meta.Rule = "unknown"
+	_, err = manager.CreateTable(nil, nil, tableSetting)
+	assert.ErrorIs(t, err, pwbtable.ErrTableInvalidCreateSetting)
+}
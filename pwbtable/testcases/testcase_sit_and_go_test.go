@@ -0,0 +1,131 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+	"github.com/weedbox/pokerface"
+)
+
+func TestTableGame_SitAndGo(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// given conditions
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck"}
+	redeemChips := int64(1000)
+	tableSetting := NewDefaultTableSetting()
+	tableSetting.Meta.Mode = pwbtable.CompetitionMode_SitAndGo
+	tableSetting.Meta.TableMaxSeatCount = 3
+	tableSetting.Meta.BlindStructure = pwbtable.TableBlindStructure{
+		Levels: []pwbtable.TableBlindLevel{
+			{Level: 1, SB: 50, BB: 100, Duration: 60},
+			{Level: 2, SB: 100, BB: 200},
+		},
+	}
+	tableSetting.Meta.SitAndGo = pwbtable.TableSitAndGoSetting{
+		Payout: pwbtable.PayoutSetting{
+			BuyIn: 1000,
+			Fee:   100,
+			Structure: pwbtable.PayoutStructure{
+				Type:        pwbtable.PayoutStructureType_Ladder,
+				Percentages: []float64{70, 30},
+			},
+		},
+	}
+
+	// create manager & table
+	var tableEngine pwbtable.TableEngine
+	var closeOnce sync.Once
+	manager := pwbtable.NewManager()
+	tableEngineOption := pwbtable.NewTableEngineOptions()
+	tableEngineOption.Interval = 3
+	tableEngineCallbacks := pwbtable.NewTableEngineCallbacks()
+	tableEngineCallbacks.OnTableUpdated = func(table *pwbtable.Table) {
+		switch table.State.Status {
+		case pwbtable.TableStateStatus_TableGamePlaying:
+			event, ok := pokerface.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok {
+				return
+			}
+
+			switch event {
+			case pokerface.GameEvent_ReadyRequested:
+				for _, playerIdx := range table.State.GamePlayerIndexes {
+					playerID := table.State.PlayerStates[playerIdx].PlayerID
+					assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+				}
+			case pokerface.GameEvent_BlindsRequested:
+				blind := table.State.BlindState
+				sbPlayerID := findPlayerID(table, "sb")
+				assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+				bbPlayerID := findPlayerID(table, "bb")
+				assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+			case pokerface.GameEvent_RoundStarted:
+				// everyone goes all-in to finish quickly
+				playerID, actions := currentPlayerMove(table)
+				if funk.Contains(actions, "allin") {
+					assert.Nil(t, tableEngine.PlayerAllin(playerID), fmt.Sprintf("%s allin error", playerID))
+				} else if funk.Contains(actions, "call") {
+					assert.Nil(t, tableEngine.PlayerCall(playerID), fmt.Sprintf("%s call error", playerID))
+				} else if funk.Contains(actions, "check") {
+					assert.Nil(t, tableEngine.PlayerCheck(playerID), fmt.Sprintf("%s check error", playerID))
+				}
+			}
+		case pwbtable.TableStateStatus_TableClosed:
+			closeOnce.Do(wg.Done)
+		}
+	}
+	tableEngineCallbacks.OnTableErrorUpdated = func(table *pwbtable.Table, err error) {
+		t.Log("[Table] Error:", err)
+	}
+	table, err := manager.CreateTable(tableEngineOption, tableEngineCallbacks, tableSetting)
+	assert.Nil(t, err, "create table failed")
+
+	tableEngine, err = manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	// the game is started by the last reservation
+	for idx, playerID := range playerIDs {
+		joinPlayer := pwbtable.JoinPlayer{PlayerID: playerID, RedeemChips: redeemChips, Seat: -1}
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", playerID))
+
+		if idx < len(playerIDs)-1 {
+			assert.Nil(t, tableEngine.PlayerJoin(playerID), fmt.Sprintf("%s join error", playerID))
+			assert.Equal(t, int64(pwbtable.UnsetValue), tableEngine.GetTable().State.StartAt)
+		}
+	}
+	assert.Nil(t, tableEngine.PlayerJoin(playerIDs[len(playerIDs)-1]), "last player join error")
+
+	wg.Wait()
+
+	// check standings
+	standings := tableEngine.GetTable().State.Standings
+	if assert.Len(t, standings, len(playerIDs)) {
+		assert.Equal(t, 1, standings[0].Place)
+		assert.Equal(t, int64(2100), standings[0].Prize)
+
+		// players busted in the same hand with the same stack split 2nd and 3rd places
+		if standings[1].Place == standings[2].Place {
+			assert.Equal(t, int64(450), standings[1].Prize)
+			assert.Equal(t, int64(450), standings[2].Prize)
+		} else {
+			assert.Equal(t, int64(900), standings[1].Prize)
+			assert.Equal(t, int64(0), standings[2].Prize)
+		}
+	}
+}
+
+func TestTableGame_SitAndGo_InvalidSetting(t *testing.T) {
+	manager := pwbtable.NewManager()
+
+	// blind structure is required
+	tableSetting := NewDefaultTableSetting()
+	tableSetting.Meta.Mode = pwbtable.CompetitionMode_SitAndGo
+	_, err := manager.CreateTable(pwbtable.NewTableEngineOptions(), pwbtable.NewTableEngineCallbacks(), tableSetting)
+	assert.Equal(t, pwbtable.ErrTableInvalidCreateSetting, err)
+}
@@ -0,0 +1,311 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+	"github.com/weedbox/pokerface"
+)
+
+func TestTableSitOut_SitOutAndSitIn(t *testing.T) {
+	// given conditions
+	tableSetting := NewDefaultTableSetting(
+		pwbtable.JoinPlayer{PlayerID: "Fred", RedeemChips: 1000, Seat: pwbtable.UnsetValue},
+		pwbtable.JoinPlayer{PlayerID: "Jeffrey", RedeemChips: 1000, Seat: pwbtable.UnsetValue},
+	)
+	tableSetting.Meta.SitOut = pwbtable.TableSitOutSetting{
+		MaxTimeouts: 2,
+		MaxDuration: 300,
+	}
+
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	table, err := manager.CreateTable(nil, nil, tableSetting)
+	assert.Nil(t, err, "create table failed")
+
+	tableEngine, err := manager.GetTableEngine(table.ID)
+	assert.Nil(t, err)
+
+	// sitting out player keeps the seat but is not able to be dealt in
+	assert.Nil(t, manager.PlayerSitOut(table.ID, "Fred"))
+	table = tableEngine.GetTable()
+	fred := table.State.PlayerStates[table.FindPlayerIdx("Fred")]
+	assert.True(t, fred.IsSittingOut)
+	assert.NotEqual(t, int64(pwbtable.UnsetValue), fred.SitOutAt)
+	assert.Equal(t, 1, len(table.ActivePlayers()))
+	assert.True(t, table.ShouldPause())
+
+	// no missed blind, no need to wait for big blind
+	assert.Nil(t, manager.PlayerSitIn(table.ID, "Fred", true))
+	table = tableEngine.GetTable()
+	fred = table.State.PlayerStates[table.FindPlayerIdx("Fred")]
+	assert.False(t, fred.IsSittingOut)
+	assert.False(t, fred.IsWaitingBB)
+	assert.Equal(t, 2, len(table.ActivePlayers()))
+
+	assert.ErrorIs(t, manager.PlayerSitOut(table.ID, "Chuck"), pwbtable.ErrTablePlayerNotFound)
+}
+
+func TestTableSitOut_CashOnly(t *testing.T) {
+	tableSetting := NewDefaultTableSetting(pwbtable.JoinPlayer{PlayerID: "Fred", RedeemChips: 1000, Seat: pwbtable.UnsetValue})
+	tableSetting.Meta.Mode = pwbtable.CompetitionMode_MTT
+
+	manager := pwbtable.NewManager()
+	defer manager.Reset()
+
+	table, err := manager.CreateTable(nil, nil, tableSetting)
+	assert.Nil(t, err, "create table failed")
+	assert.ErrorIs(t, manager.PlayerSitOut(table.ID, "Fred"), pwbtable.ErrTablePlayerInvalidAction)
+	assert.ErrorIs(t, manager.PlayerSitIn(table.ID, "Fred", false), pwbtable.ErrTablePlayerInvalidAction)
+}
+
+func newSitOutTableSetting() pwbtable.TableSetting {
+	// Chuck sits between Jeffrey and Fred, big blind passes him when Jeffrey is dealer of heads-up
+	tableSetting := NewDefaultTableSetting(
+		pwbtable.JoinPlayer{PlayerID: "Fred", RedeemChips: 1000, Seat: 0},
+		pwbtable.JoinPlayer{PlayerID: "Jeffrey", RedeemChips: 1000, Seat: 1},
+		pwbtable.JoinPlayer{PlayerID: "Chuck", RedeemChips: 1000, Seat: 2},
+	)
+	tableSetting.Meta.TableMaxSeatCount = 3
+	return tableSetting
+}
+
+// playSitOutHands plays hands in which players check or fold unless isIdle, it stops once isDone returns true.
+// isDone is also called while the table is locked, so it must guard calls to table engine by itself.
+func playSitOutHands(t *testing.T, tableSetting pwbtable.TableSetting, sitOutPlayerIDs []string, isIdle bool, isDone func(tableEngine pwbtable.TableEngine, table *pwbtable.Table) bool) pwbtable.TableEngine {
+	var wg sync.WaitGroup
+	var once sync.Once
+	wg.Add(1)
+
+	var tableEngine pwbtable.TableEngine
+	manager := pwbtable.NewManager()
+	tableEngineOption := pwbtable.NewTableEngineOptions()
+	tableEngineOption.Interval = 1
+	tableEngineCallbacks := pwbtable.NewTableEngineCallbacks()
+	tableEngineCallbacks.OnTableUpdated = func(table *pwbtable.Table) {
+		if tableEngine == nil {
+			return
+		}
+
+		if isDone(tableEngine, table) {
+			once.Do(wg.Done)
+			return
+		}
+
+		if table.State.Status != pwbtable.TableStateStatus_TableGamePlaying {
+			return
+		}
+
+		event, ok := pokerface.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+		if !ok {
+			return
+		}
+
+		switch event {
+		case pokerface.GameEvent_ReadyRequested:
+			for _, playerIdx := range table.State.GamePlayerIndexes {
+				playerID := table.State.PlayerStates[playerIdx].PlayerID
+				assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+			}
+		case pokerface.GameEvent_BlindsRequested:
+			blind := table.State.BlindState
+
+			sbPlayerID := findPlayerID(table, "sb")
+			assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+
+			bbPlayerID := findPlayerID(table, "bb")
+			assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+		case pokerface.GameEvent_RoundStarted:
+			if isIdle {
+				return
+			}
+
+			playerID, actions := currentPlayerMove(table)
+			if funk.Contains(actions, "check") {
+				assert.Nil(t, tableEngine.PlayerCheck(playerID), fmt.Sprintf("%s check error", playerID))
+			} else {
+				assert.Nil(t, tableEngine.PlayerFold(playerID), fmt.Sprintf("%s fold error", playerID))
+			}
+		}
+	}
+	tableEngineCallbacks.OnTableErrorUpdated = func(table *pwbtable.Table, err error) {
+		t.Log("[Table] Error:", err)
+	}
+
+	tableSetting.JoinPlayers = nil
+	table, err := manager.CreateTable(tableEngineOption, tableEngineCallbacks, tableSetting)
+	assert.Nil(t, err, "create table failed")
+
+	engine, err := manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	// players buy in
+	for _, joinPlayer := range newSitOutTableSetting().JoinPlayers {
+		assert.Nil(t, engine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", joinPlayer.PlayerID))
+		assert.Nil(t, engine.PlayerJoin(joinPlayer.PlayerID), fmt.Sprintf("%s join error", joinPlayer.PlayerID))
+	}
+
+	for _, playerID := range sitOutPlayerIDs {
+		assert.Nil(t, engine.PlayerSitOut(playerID), fmt.Sprintf("%s sit out error", playerID))
+	}
+
+	// start game
+	tableEngine = engine
+	tableEngine.UpdateBlind(1, 0, 0, 10, 20)
+	assert.Nil(t, tableEngine.StartTableGame(), "start table game failed")
+
+	wg.Wait()
+
+	// stop the next game
+	assert.Nil(t, manager.CloseTable(table.ID), "close table failed")
+	return tableEngine
+}
+
+func isGameClosed(table *pwbtable.Table) bool {
+	return table.State.Status == pwbtable.TableStateStatus_TableGameSettled &&
+		table.State.GameState.Status.CurrentEvent == pokerface.GameEventSymbols[pokerface.GameEvent_GameClosed]
+}
+
+func TestTableSitOut_DeadBlind(t *testing.T) {
+	isSatIn := false
+	isDeadBlindChecked := false
+	playSitOutHands(t, newSitOutTableSetting(), []string{"Chuck"}, false, func(tableEngine pwbtable.TableEngine, table *pwbtable.Table) bool {
+		chuck := table.State.PlayerStates[table.FindPlayerIdx("Chuck")]
+
+		switch {
+		case isGameClosed(table) && table.State.GameCount == 2 && !isSatIn:
+			// big blind passes Chuck in one of the heads-up hands
+			isSatIn = true
+			assert.Equal(t, int64(20), chuck.MissedBlinds)
+			assert.Equal(t, int64(1000), chuck.Bankroll)
+			assert.Nil(t, tableEngine.PlayerSitIn("Chuck", false), "Chuck sit in error")
+		case table.State.Status == pwbtable.TableStateStatus_TableGameOpened && table.State.GameCount == 3 && !isDeadBlindChecked:
+			// dead blind is posted unless Chuck is big blind
+			isDeadBlindChecked = true
+			assert.NotEqual(t, pwbtable.UnsetValue, table.FindGamePlayerIdx("Chuck"))
+			assert.Equal(t, int64(0), chuck.MissedBlinds)
+			if funk.Contains(chuck.Positions, "bb") {
+				assert.Equal(t, int64(0), table.State.DeadBlinds)
+				assert.Equal(t, int64(1000), chuck.Bankroll)
+			} else {
+				assert.Equal(t, int64(20), table.State.DeadBlinds)
+				assert.Equal(t, int64(980), chuck.Bankroll)
+			}
+		case isGameClosed(table) && table.State.GameCount == 3:
+			// dead blind goes to the winner
+			total := int64(0)
+			for _, player := range table.State.PlayerStates {
+				total += player.Bankroll
+			}
+			assert.Equal(t, int64(3000), total)
+			assert.Equal(t, int64(0), table.State.DeadBlinds)
+			return true
+		}
+		return false
+	})
+	assert.True(t, isDeadBlindChecked)
+}
+
+func TestTableSitOut_WaitForBB(t *testing.T) {
+	isSatIn := false
+	playSitOutHands(t, newSitOutTableSetting(), []string{"Chuck"}, false, func(tableEngine pwbtable.TableEngine, table *pwbtable.Table) bool {
+		chuck := table.State.PlayerStates[table.FindPlayerIdx("Chuck")]
+
+		switch {
+		case isGameClosed(table) && table.State.GameCount == 2 && !isSatIn:
+			isSatIn = true
+			assert.Nil(t, tableEngine.PlayerSitIn("Chuck", true), "Chuck sit in error")
+			assert.True(t, chuck.IsWaitingBB)
+			assert.Equal(t, int64(0), chuck.MissedBlinds)
+		case table.State.Status == pwbtable.TableStateStatus_TableGameOpened && table.State.GameCount > 2:
+			// Chuck is only dealt in as big blind without dead blind
+			if table.FindGamePlayerIdx("Chuck") == pwbtable.UnsetValue {
+				assert.True(t, chuck.IsWaitingBB)
+				assert.LessOrEqual(t, table.State.GameCount, 4, "Chuck waits too long")
+				return table.State.GameCount > 4
+			}
+
+			assert.False(t, chuck.IsWaitingBB)
+			assert.Contains(t, chuck.Positions, "bb")
+			assert.Equal(t, int64(0), table.State.DeadBlinds)
+			assert.Equal(t, int64(1000), chuck.Bankroll)
+			return true
+		}
+		return false
+	})
+}
+
+func TestTableSitOut_MaxTimeouts(t *testing.T) {
+	tableSetting := newSitOutTableSetting()
+	tableSetting.Meta.ActionTime = 1
+	tableSetting.Meta.SitOut.MaxTimeouts = 1
+
+	// Chuck sits out, the first player to act in heads-up times out and sits out as well
+	tableEngine := playSitOutHands(t, tableSetting, []string{"Chuck"}, true, func(tableEngine pwbtable.TableEngine, table *pwbtable.Table) bool {
+		return table.State.Status == pwbtable.TableStateStatus_TablePausing
+	})
+
+	table := tableEngine.GetTable()
+	assert.Equal(t, 1, len(table.ActivePlayers()))
+	sittingOutPlayers := funk.Filter(table.State.PlayerStates, func(player *pwbtable.TablePlayerState) bool {
+		return player.IsSittingOut && player.PlayerID != "Chuck"
+	}).([]*pwbtable.TablePlayerState)
+	if assert.Len(t, sittingOutPlayers, 1) {
+		assert.Equal(t, 1, sittingOutPlayers[0].TimeoutCount)
+	}
+}
+
+func TestTableSitOut_MaxDuration(t *testing.T) {
+	tableSetting := newSitOutTableSetting()
+	tableSetting.Meta.SitOut.MaxDuration = 1
+
+	var mu sync.Mutex
+	var leftPlayerState *pwbtable.TablePlayerState
+	var leftEvent *pwbtable.TableEvent
+	isSubscribed := false
+	playSitOutHands(t, tableSetting, []string{"Chuck"}, false, func(tableEngine pwbtable.TableEngine, table *pwbtable.Table) bool {
+		switch {
+		case table.State.Status == pwbtable.TableStateStatus_TableGameOpened && table.State.GameCount == 1 && !isSubscribed:
+			// evicted player leaves like any other leaving player
+			isSubscribed = true
+			tableEngine.OnTablePlayerStateUpdated(func(competitionID, tableID string, playerState *pwbtable.TablePlayerState) {
+				mu.Lock()
+				defer mu.Unlock()
+				if playerState.PlayerID == "Chuck" {
+					leftPlayerState = playerState
+				}
+			})
+			tableEngine.SubscribeTableEventsFunc(nil, func(event *pwbtable.TableEvent) {
+				mu.Lock()
+				defer mu.Unlock()
+				if event.Kind == pwbtable.TableEventKind_PlayersLeft {
+					leftEvent = event
+				}
+			})
+
+			// Chuck has sat out long enough once the hand is over
+			time.Sleep(2 * time.Second)
+		case table.State.Status == pwbtable.TableStateStatus_TableGameOpened && table.State.GameCount == 2:
+			assert.Equal(t, pwbtable.UnsetValue, table.FindPlayerIdx("Chuck"), "Chuck is not evicted")
+			assert.Len(t, table.State.PlayerStates, 2)
+			return true
+		}
+		return false
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if assert.NotNil(t, leftPlayerState, "no final state of Chuck") {
+		assert.False(t, leftPlayerState.IsIn)
+		assert.Equal(t, pwbtable.UnsetValue, leftPlayerState.Seat)
+	}
+	if assert.NotNil(t, leftEvent, "no players left event") {
+		assert.Equal(t, []string{"Chuck"}, leftEvent.Payload)
+	}
+}
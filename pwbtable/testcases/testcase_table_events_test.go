@@ -0,0 +1,120 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+)
+
+func TestTableEngine_TableEvents_Subscription(t *testing.T) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	tableEngine := pwbtable.NewTableEngine(pwbtable.NewTableEngineOptions(), pwbtable.WithGameBackend(pwbtable.NewNativeGameBackend()))
+
+	// channel based subscriber
+	sub := tableEngine.SubscribeTableEvents(pwbtable.NewTableEventSubscriptionOptions())
+	channelEvents := make([]*pwbtable.TableEvent, 0)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for event := range sub.Events() {
+			channelEvents = append(channelEvents, event)
+		}
+	}()
+
+	// callback based subscriber
+	funcOptions := pwbtable.NewTableEventSubscriptionOptions()
+	funcOptions.BackPressure = pwbtable.TableEventBackPressure_DropOldest
+	funcEventKinds := make([]pwbtable.TableEventKind, 0)
+	tableEngine.SubscribeTableEventsFunc(funcOptions, func(event *pwbtable.TableEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		funcEventKinds = append(funcEventKinds, event.Kind)
+	})
+
+	// unsubscribed subscriber receives nothing
+	unsub := tableEngine.SubscribeTableEvents(pwbtable.NewTableEventSubscriptionOptions())
+	unsub.Unsubscribe()
+	_, ok := <-unsub.Events()
+	assert.False(t, ok, "events channel should be closed after unsubscribe")
+
+	// create table & reserve players
+	table, err := tableEngine.CreateTable(NewDefaultTableSetting())
+	assert.Nil(t, err, "create table failed")
+
+	playerIDs := []string{"Fred", "Jeffrey"}
+	for _, playerID := range playerIDs {
+		joinPlayer := pwbtable.JoinPlayer{PlayerID: playerID, RedeemChips: 1000, Seat: -1}
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", playerID))
+	}
+	assert.Nil(t, tableEngine.CloseTable(), "close table failed")
+
+	// channel is closed when table is closed
+	wg.Wait()
+
+	expectedKinds := []pwbtable.TableEventKind{
+		pwbtable.TableEventKind_TableCreated,
+		pwbtable.TableEventKind_PlayerReserved,
+		pwbtable.TableEventKind_PlayerReserved,
+		pwbtable.TableEventKind_TableClosed,
+	}
+	assert.Len(t, channelEvents, len(expectedKinds))
+	for idx, event := range channelEvents {
+		assert.Equal(t, expectedKinds[idx], event.Kind)
+		assert.Equal(t, table.ID, event.TableID)
+		if idx > 0 {
+			assert.Greater(t, event.Serial, channelEvents[idx-1].Serial)
+		}
+	}
+	assert.Equal(t, playerIDs[0], channelEvents[1].PlayerID)
+	assert.Equal(t, playerIDs[1], channelEvents[2].PlayerID)
+	assert.Equal(t, int64(0), sub.Dropped())
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(funcEventKinds) == len(expectedKinds)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestTableEngine_TableEvents_SlowSubscriber(t *testing.T) {
+	tableEngine := pwbtable.NewTableEngine(pwbtable.NewTableEngineOptions(), pwbtable.WithGameBackend(pwbtable.NewNativeGameBackend()))
+
+	// subscriber never drains its buffer
+	options := pwbtable.NewTableEventSubscriptionOptions()
+	options.BufferSize = 1
+	sub := tableEngine.SubscribeTableEvents(options)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		_, err := tableEngine.CreateTable(NewDefaultTableSetting())
+		assert.Nil(t, err, "create table failed")
+
+		for _, playerID := range []string{"Fred", "Jeffrey"} {
+			joinPlayer := pwbtable.JoinPlayer{PlayerID: playerID, RedeemChips: 1000, Seat: -1}
+			assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", playerID))
+		}
+	}()
+
+	// table is not held up by the subscriber by default
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("table is blocked by slow subscriber")
+	}
+
+	// only the latest event is kept
+	assert.Equal(t, int64(2), sub.Dropped())
+	event := <-sub.Events()
+	assert.Equal(t, pwbtable.TableEventKind_PlayerReserved, event.Kind)
+	assert.Equal(t, "Jeffrey", event.PlayerID)
+
+	sub.Unsubscribe()
+}
@@ -0,0 +1,103 @@
+package testcases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/settlement"
+)
+
+func newViewTestTable(result *settlement.Result, folds ...bool) pwbtable.Table {
+	playerIDs := []string{"Fred", "Jeffrey", "Chuck"}
+	holeCards := [][]string{{"SA", "SK"}, {"H2", "D7"}, {"CQ", "CJ"}}
+
+	table := pwbtable.Table{
+		ID: "view-table",
+		State: &pwbtable.TableState{
+			PlayerStates:      make([]*pwbtable.TablePlayerState, 0),
+			GamePlayerIndexes: []int{0, 1, 2},
+			GameState: &pokerface.GameState{
+				Meta: pokerface.Meta{
+					Deck: []string{"S2", "S3", "S4"},
+				},
+				Status: pokerface.Status{
+					Burned: []string{"S5", "S6"},
+				},
+				Players: make([]*pokerface.PlayerState, 0),
+				Result:  result,
+			},
+		},
+	}
+
+	for idx, playerID := range playerIDs {
+		table.State.PlayerStates = append(table.State.PlayerStates, &pwbtable.TablePlayerState{
+			PlayerID: playerID,
+			Seat:     idx,
+		})
+		table.State.GameState.Players = append(table.State.GameState.Players, &pokerface.PlayerState{
+			Idx:       idx,
+			HoleCards: holeCards[idx],
+			StackSize: 1000,
+			Fold:      idx < len(folds) && folds[idx],
+		})
+	}
+
+	return table
+}
+
+func TestTableView_DuringGame(t *testing.T) {
+	table := newViewTestTable(nil)
+
+	view, err := table.ViewFor("Jeffrey")
+	assert.Nil(t, err, "view for player failed")
+	assert.Empty(t, view.State.GameState.Meta.Deck)
+	assert.Empty(t, view.State.GameState.Status.Burned)
+	assert.Equal(t, []string{"H2", "D7"}, view.State.GameState.Players[1].HoleCards)
+	assert.Empty(t, view.State.GameState.Players[0].HoleCards)
+	assert.Empty(t, view.State.GameState.Players[2].HoleCards)
+
+	spectatorView, err := table.SpectatorView()
+	assert.Nil(t, err, "spectator view failed")
+	assert.Empty(t, spectatorView.State.GameState.Status.Burned)
+	for _, player := range spectatorView.State.GameState.Players {
+		assert.Empty(t, player.HoleCards)
+	}
+
+	// original table is untouched
+	assert.Equal(t, []string{"S2", "S3", "S4"}, table.State.GameState.Meta.Deck)
+	assert.Equal(t, []string{"S5", "S6"}, table.State.GameState.Status.Burned)
+	assert.Equal(t, []string{"SA", "SK"}, table.State.GameState.Players[0].HoleCards)
+}
+
+func TestTableView_Showdown(t *testing.T) {
+	result := &settlement.Result{
+		Pots: []*settlement.PotResult{
+			{Total: 2000, Winners: []*settlement.Winner{{Idx: 0, Withdraw: 2000}}},
+		},
+	}
+	table := newViewTestTable(result, false, true, false)
+
+	view, err := table.SpectatorView()
+	assert.Nil(t, err, "spectator view failed")
+
+	// winner shows, folded player and loser muck
+	assert.Equal(t, []string{"SA", "SK"}, view.State.GameState.Players[0].HoleCards)
+	assert.Empty(t, view.State.GameState.Players[1].HoleCards)
+	assert.Empty(t, view.State.GameState.Players[2].HoleCards)
+}
+
+func TestTableView_UncontestedPot(t *testing.T) {
+	result := &settlement.Result{
+		Pots: []*settlement.PotResult{
+			{Total: 30, Winners: []*settlement.Winner{{Idx: 2, Withdraw: 30}}},
+		},
+	}
+	table := newViewTestTable(result, true, true, false)
+
+	view, err := table.ViewFor("Fred")
+	assert.Nil(t, err, "view for player failed")
+	assert.Equal(t, []string{"SA", "SK"}, view.State.GameState.Players[0].HoleCards)
+	assert.Empty(t, view.State.GameState.Players[2].HoleCards)
+}
@@ -0,0 +1,157 @@
+package testcases
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thoas/go-funk"
+	"github.com/weedbox/PokerWeedBox/pwbtable"
+	"github.com/weedbox/pokerface"
+)
+
+func TestTableGame_ExtendTime(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// given conditions
+	playerIDs := []string{"Fred", "Jeffrey"}
+	redeemChips := int64(15000)
+	players := funk.Map(playerIDs, func(playerID string) pwbtable.JoinPlayer {
+		return pwbtable.JoinPlayer{
+			PlayerID:    playerID,
+			RedeemChips: redeemChips,
+			Seat:        -1,
+		}
+	}).([]pwbtable.JoinPlayer)
+
+	// create manager & table
+	var tableEngine pwbtable.TableEngine
+	isExtended := false
+	manager := pwbtable.NewManager()
+	tableEngineOption := pwbtable.NewTableEngineOptions()
+	tableEngineOption.Interval = 3
+	tableEngineCallbacks := pwbtable.NewTableEngineCallbacks()
+	tableEngineCallbacks.OnTableUpdated = func(table *pwbtable.Table) {
+		switch table.State.Status {
+		case pwbtable.TableStateStatus_TableGamePlaying:
+			event, ok := pokerface.GameEventBySymbol[table.State.GameState.Status.CurrentEvent]
+			if !ok {
+				return
+			}
+
+			switch event {
+			case pokerface.GameEvent_ReadyRequested:
+				for _, playerID := range playerIDs {
+					assert.Nil(t, tableEngine.PlayerReady(playerID), fmt.Sprintf("%s ready error", playerID))
+				}
+			case pokerface.GameEvent_BlindsRequested:
+				blind := table.State.BlindState
+
+				sbPlayerID := findPlayerID(table, "sb")
+				assert.Nil(t, tableEngine.PlayerPay(sbPlayerID, blind.SB), fmt.Sprintf("%s pay sb error", sbPlayerID))
+
+				bbPlayerID := findPlayerID(table, "bb")
+				assert.Nil(t, tableEngine.PlayerPay(bbPlayerID, blind.BB), fmt.Sprintf("%s pay bb error", bbPlayerID))
+			case pokerface.GameEvent_RoundStarted:
+				// table is updated again while time is extended
+				if isExtended {
+					return
+				}
+				isExtended = true
+
+				playerID, _ := currentPlayerMove(table)
+				otherPlayerID := playerIDs[0]
+				if otherPlayerID == playerID {
+					otherPlayerID = playerIDs[1]
+				}
+				playerState := table.State.PlayerStates[table.FindPlayerIdx(playerID)]
+				actionEndAt := table.State.ActionEndAt
+
+				// not your turn
+				assert.ErrorIs(t, tableEngine.PlayerExtendTime(otherPlayerID, 3), pwbtable.ErrTablePlayerInvalidAction)
+				assert.ErrorIs(t, tableEngine.PlayerExtendTime(playerID, 0), pwbtable.ErrTablePlayerInvalidAction)
+
+				// extend
+				assert.Nil(t, tableEngine.PlayerExtendTime(playerID, 3), fmt.Sprintf("%s extend time error", playerID))
+				assert.Equal(t, 2, playerState.TimeBank)
+				assert.Equal(t, actionEndAt+3, table.State.ActionEndAt)
+
+				// capped at the remaining bank
+				assert.Nil(t, tableEngine.PlayerExtendTime(playerID, 10), fmt.Sprintf("%s extend time error", playerID))
+				assert.Equal(t, 0, playerState.TimeBank)
+				assert.Equal(t, actionEndAt+5, table.State.ActionEndAt)
+
+				// no bank left
+				assert.ErrorIs(t, tableEngine.PlayerExtendTime(playerID, 1), pwbtable.ErrTablePlayerNoTimeBank)
+
+				assert.Nil(t, tableEngine.PlayerFold(playerID), fmt.Sprintf("%s fold error", playerID))
+			}
+		case pwbtable.TableStateStatus_TableGameSettled:
+			if table.State.GameState.Status.CurrentEvent == pokerface.GameEventSymbols[pokerface.GameEvent_GameClosed] {
+				wg.Done()
+				return
+			}
+		}
+	}
+	tableEngineCallbacks.OnTableErrorUpdated = func(table *pwbtable.Table, err error) {
+		t.Log("[Table] Error:", err)
+	}
+	tableSetting := NewDefaultTableSetting()
+	tableSetting.Meta.TimeBank = pwbtable.TableTimeBankSetting{
+		InitialSeconds: 5,
+	}
+	table, err := manager.CreateTable(tableEngineOption, tableEngineCallbacks, tableSetting)
+	assert.Nil(t, err, "create table failed")
+
+	// get table engine
+	tableEngine, err = manager.GetTableEngine(table.ID)
+	assert.Nil(t, err, "get table engine failed")
+
+	// players buy in
+	for _, joinPlayer := range players {
+		assert.Nil(t, tableEngine.PlayerReserve(joinPlayer), fmt.Sprintf("%s reserve error", joinPlayer.PlayerID))
+		assert.Nil(t, tableEngine.PlayerJoin(joinPlayer.PlayerID), fmt.Sprintf("%s join error", joinPlayer.PlayerID))
+	}
+
+	// start game
+	tableEngine.UpdateBlind(1, 0, 0, 10, 20)
+	assert.Nil(t, tableEngine.StartTableGame(), "start table game failed")
+
+	wg.Wait()
+
+	// stop the next game
+	assert.Nil(t, manager.CloseTable(table.ID), "close table failed")
+}
+
+func TestTableTimeBank_Replenish(t *testing.T) {
+	setting := pwbtable.TableTimeBankSetting{
+		InitialSeconds:   5,
+		MaxSeconds:       12,
+		ReplenishSeconds: 4,
+		ReplenishHands:   3,
+	}
+	playerState := &pwbtable.TablePlayerState{TimeBank: setting.InitialSeconds}
+
+	// replenished every 3 hands
+	for hand := 1; hand <= 2; hand++ {
+		setting.Replenish(playerState)
+		assert.Equal(t, 5, playerState.TimeBank)
+	}
+	setting.Replenish(playerState)
+	assert.Equal(t, 9, playerState.TimeBank)
+
+	// capped at max seconds
+	for hand := 1; hand <= 3; hand++ {
+		setting.Replenish(playerState)
+	}
+	assert.Equal(t, 12, playerState.TimeBank)
+
+	// never replenished without replenish hands
+	playerState = &pwbtable.TablePlayerState{}
+	for hand := 1; hand <= 3; hand++ {
+		pwbtable.TableTimeBankSetting{ReplenishSeconds: 4}.Replenish(playerState)
+	}
+	assert.Equal(t, 0, playerState.TimeBank)
+}